@@ -49,6 +49,8 @@ type LetStatement struct {
 	Token token.Token
 	Name  *Identifier
 	Value Expression
+	// Constant is set for const bindings, which may not be reassigned.
+	Constant bool
 }
 
 func (ls *LetStatement) statementNode() {}
@@ -172,3 +174,109 @@ func (infix *InfixExpression) String() string {
 	out.WriteString(")")
 	return out.String()
 }
+
+type BlockStatement struct {
+	Token      token.Token
+	Statements []Statement
+}
+
+func (b *BlockStatement) statementNode() {}
+func (b *BlockStatement) TokenLiteral() string {
+	return b.Token.Literal
+}
+func (b *BlockStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString("{")
+	for _, stmt := range b.Statements {
+		out.WriteString(" " + stmt.String())
+	}
+	out.WriteString(" }")
+	return out.String()
+}
+
+// while (x < 10) { ... }
+type WhileStatement struct {
+	Token     token.Token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+func (w *WhileStatement) statementNode() {}
+func (w *WhileStatement) TokenLiteral() string {
+	return w.Token.Literal
+}
+func (w *WhileStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString("while (")
+	out.WriteString(w.Condition.String())
+	out.WriteString(") ")
+	out.WriteString(w.Body.String())
+	return out.String()
+}
+
+// for (x in xs) { ... }
+type ForStatement struct {
+	Token    token.Token
+	Variable *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+func (f *ForStatement) statementNode() {}
+func (f *ForStatement) TokenLiteral() string {
+	return f.Token.Literal
+}
+func (f *ForStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString("for ")
+	out.WriteString("(" + f.Variable.String() + " in " + f.Iterable.String() + ")")
+	out.WriteString(" ")
+	out.WriteString(f.Body.String())
+	return out.String()
+}
+
+type BreakStatement struct {
+	Token token.Token
+}
+
+func (b *BreakStatement) statementNode() {}
+func (b *BreakStatement) TokenLiteral() string {
+	return b.Token.Literal
+}
+func (b *BreakStatement) String() string {
+	return b.TokenLiteral() + ";"
+}
+
+type ContinueStatement struct {
+	Token token.Token
+}
+
+func (c *ContinueStatement) statementNode() {}
+func (c *ContinueStatement) TokenLiteral() string {
+	return c.Token.Literal
+}
+func (c *ContinueStatement) String() string {
+	return c.TokenLiteral() + ";"
+}
+
+// x = 5; x += 1;
+type AssignExpression struct {
+	Token    token.Token
+	Name     *Identifier
+	Operator string
+	Value    Expression
+}
+
+func (a *AssignExpression) expressionNode() {}
+func (a *AssignExpression) TokenLiteral() string {
+	return a.Token.Literal
+}
+func (a *AssignExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(a.Name.String())
+	out.WriteString(" " + a.Operator + " ")
+	out.WriteString(a.Value.String())
+	out.WriteString(")")
+	return out.String()
+}
@@ -0,0 +1,427 @@
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nishokbanand/interpreter/token"
+)
+
+// Every node encodes to a JSON object whose "kind" field names its type,
+// with its token under "token" and its children under lower camel case
+// field names. Missing children encode as null.
+
+func (p *Program) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind       string      `json:"kind"`
+		Statements []Statement `json:"statements"`
+	}{"Program", p.Statements})
+}
+
+func (p *Program) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Statements []json.RawMessage `json:"statements"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	stmts, err := decodeStatements(v.Statements)
+	if err != nil {
+		return err
+	}
+	p.Statements = stmts
+	return nil
+}
+
+func (ls *LetStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string      `json:"kind"`
+		Token    token.Token `json:"token"`
+		Name     *Identifier `json:"name"`
+		Value    Expression  `json:"value"`
+		Constant bool        `json:"constant"`
+	}{"LetStatement", ls.Token, ls.Name, ls.Value, ls.Constant})
+}
+
+func (ls *LetStatement) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token    token.Token     `json:"token"`
+		Name     json.RawMessage `json:"name"`
+		Value    json.RawMessage `json:"value"`
+		Constant bool            `json:"constant"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	name, err := decodeIdentifier(v.Name)
+	if err != nil {
+		return err
+	}
+	value, err := decodeExpression(v.Value)
+	if err != nil {
+		return err
+	}
+	*ls = LetStatement{Token: v.Token, Name: name, Value: value, Constant: v.Constant}
+	return nil
+}
+
+func (i *Identifier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string      `json:"kind"`
+		Token token.Token `json:"token"`
+		Value string      `json:"value"`
+	}{"Identifier", i.Token, i.Value})
+}
+
+func (r *ReturnStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string      `json:"kind"`
+		Token       token.Token `json:"token"`
+		ReturnValue Expression  `json:"returnValue"`
+	}{"ReturnStatement", r.Token, r.ReturnValue})
+}
+
+func (r *ReturnStatement) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token       token.Token     `json:"token"`
+		ReturnValue json.RawMessage `json:"returnValue"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	value, err := decodeExpression(v.ReturnValue)
+	if err != nil {
+		return err
+	}
+	*r = ReturnStatement{Token: v.Token, ReturnValue: value}
+	return nil
+}
+
+func (e *ExpressionStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind       string      `json:"kind"`
+		Token      token.Token `json:"token"`
+		Expression Expression  `json:"expression"`
+	}{"ExpressionStatement", e.Token, e.Expression})
+}
+
+func (e *ExpressionStatement) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token      token.Token     `json:"token"`
+		Expression json.RawMessage `json:"expression"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	exp, err := decodeExpression(v.Expression)
+	if err != nil {
+		return err
+	}
+	*e = ExpressionStatement{Token: v.Token, Expression: exp}
+	return nil
+}
+
+func (i *IntegerLiteral) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string      `json:"kind"`
+		Token token.Token `json:"token"`
+		Value int64       `json:"value"`
+	}{"IntegerLiteral", i.Token, i.Value})
+}
+
+func (pre *PrefixExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string      `json:"kind"`
+		Token    token.Token `json:"token"`
+		Operator string      `json:"operator"`
+		Right    Expression  `json:"right"`
+	}{"PrefixExpression", pre.Token, pre.Operator, pre.Right})
+}
+
+func (pre *PrefixExpression) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token    token.Token     `json:"token"`
+		Operator string          `json:"operator"`
+		Right    json.RawMessage `json:"right"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	right, err := decodeExpression(v.Right)
+	if err != nil {
+		return err
+	}
+	*pre = PrefixExpression{Token: v.Token, Operator: v.Operator, Right: right}
+	return nil
+}
+
+func (infix *InfixExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string      `json:"kind"`
+		Token    token.Token `json:"token"`
+		Left     Expression  `json:"left"`
+		Operator string      `json:"operator"`
+		Right    Expression  `json:"right"`
+	}{"InfixExpression", infix.Token, infix.Left, infix.Operator, infix.Right})
+}
+
+func (infix *InfixExpression) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token    token.Token     `json:"token"`
+		Left     json.RawMessage `json:"left"`
+		Operator string          `json:"operator"`
+		Right    json.RawMessage `json:"right"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	left, err := decodeExpression(v.Left)
+	if err != nil {
+		return err
+	}
+	right, err := decodeExpression(v.Right)
+	if err != nil {
+		return err
+	}
+	*infix = InfixExpression{Token: v.Token, Left: left, Operator: v.Operator, Right: right}
+	return nil
+}
+
+func (b *BlockStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind       string      `json:"kind"`
+		Token      token.Token `json:"token"`
+		Statements []Statement `json:"statements"`
+	}{"BlockStatement", b.Token, b.Statements})
+}
+
+func (b *BlockStatement) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token      token.Token       `json:"token"`
+		Statements []json.RawMessage `json:"statements"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	stmts, err := decodeStatements(v.Statements)
+	if err != nil {
+		return err
+	}
+	*b = BlockStatement{Token: v.Token, Statements: stmts}
+	return nil
+}
+
+func (w *WhileStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind      string          `json:"kind"`
+		Token     token.Token     `json:"token"`
+		Condition Expression      `json:"condition"`
+		Body      *BlockStatement `json:"body"`
+	}{"WhileStatement", w.Token, w.Condition, w.Body})
+}
+
+func (w *WhileStatement) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token     token.Token     `json:"token"`
+		Condition json.RawMessage `json:"condition"`
+		Body      json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	condition, err := decodeExpression(v.Condition)
+	if err != nil {
+		return err
+	}
+	body, err := decodeBlock(v.Body)
+	if err != nil {
+		return err
+	}
+	*w = WhileStatement{Token: v.Token, Condition: condition, Body: body}
+	return nil
+}
+
+func (f *ForStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string          `json:"kind"`
+		Token    token.Token     `json:"token"`
+		Variable *Identifier     `json:"variable"`
+		Iterable Expression      `json:"iterable"`
+		Body     *BlockStatement `json:"body"`
+	}{"ForStatement", f.Token, f.Variable, f.Iterable, f.Body})
+}
+
+func (f *ForStatement) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token    token.Token     `json:"token"`
+		Variable json.RawMessage `json:"variable"`
+		Iterable json.RawMessage `json:"iterable"`
+		Body     json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	variable, err := decodeIdentifier(v.Variable)
+	if err != nil {
+		return err
+	}
+	iterable, err := decodeExpression(v.Iterable)
+	if err != nil {
+		return err
+	}
+	body, err := decodeBlock(v.Body)
+	if err != nil {
+		return err
+	}
+	*f = ForStatement{Token: v.Token, Variable: variable, Iterable: iterable, Body: body}
+	return nil
+}
+
+func (b *BreakStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string      `json:"kind"`
+		Token token.Token `json:"token"`
+	}{"BreakStatement", b.Token})
+}
+
+func (c *ContinueStatement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string      `json:"kind"`
+		Token token.Token `json:"token"`
+	}{"ContinueStatement", c.Token})
+}
+
+func (a *AssignExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind     string      `json:"kind"`
+		Token    token.Token `json:"token"`
+		Name     *Identifier `json:"name"`
+		Operator string      `json:"operator"`
+		Value    Expression  `json:"value"`
+	}{"AssignExpression", a.Token, a.Name, a.Operator, a.Value})
+}
+
+func (a *AssignExpression) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Token    token.Token     `json:"token"`
+		Name     json.RawMessage `json:"name"`
+		Operator string          `json:"operator"`
+		Value    json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	name, err := decodeIdentifier(v.Name)
+	if err != nil {
+		return err
+	}
+	value, err := decodeExpression(v.Value)
+	if err != nil {
+		return err
+	}
+	*a = AssignExpression{Token: v.Token, Name: name, Operator: v.Operator, Value: value}
+	return nil
+}
+
+// UnmarshalNode decodes a node of any kind from its JSON encoding. It
+// returns nil for JSON null.
+func UnmarshalNode(data []byte) (Node, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var head struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+	var node Node
+	switch head.Kind {
+	case "Program":
+		node = &Program{}
+	case "LetStatement":
+		node = &LetStatement{}
+	case "Identifier":
+		node = &Identifier{}
+	case "ReturnStatement":
+		node = &ReturnStatement{}
+	case "ExpressionStatement":
+		node = &ExpressionStatement{}
+	case "IntegerLiteral":
+		node = &IntegerLiteral{}
+	case "PrefixExpression":
+		node = &PrefixExpression{}
+	case "InfixExpression":
+		node = &InfixExpression{}
+	case "BlockStatement":
+		node = &BlockStatement{}
+	case "WhileStatement":
+		node = &WhileStatement{}
+	case "ForStatement":
+		node = &ForStatement{}
+	case "BreakStatement":
+		node = &BreakStatement{}
+	case "ContinueStatement":
+		node = &ContinueStatement{}
+	case "AssignExpression":
+		node = &AssignExpression{}
+	default:
+		return nil, fmt.Errorf("ast: unknown node kind %q", head.Kind)
+	}
+	if err := json.Unmarshal(data, node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func decodeStatements(raw []json.RawMessage) ([]Statement, error) {
+	stmts := []Statement{}
+	for _, data := range raw {
+		node, err := UnmarshalNode(data)
+		if err != nil {
+			return nil, err
+		}
+		stmt, ok := node.(Statement)
+		if !ok {
+			return nil, fmt.Errorf("ast: %T is not a statement", node)
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+func decodeExpression(data json.RawMessage) (Expression, error) {
+	node, err := UnmarshalNode(data)
+	if err != nil || node == nil {
+		return nil, err
+	}
+	exp, ok := node.(Expression)
+	if !ok {
+		return nil, fmt.Errorf("ast: %T is not an expression", node)
+	}
+	return exp, nil
+}
+
+func decodeIdentifier(data json.RawMessage) (*Identifier, error) {
+	node, err := UnmarshalNode(data)
+	if err != nil || node == nil {
+		return nil, err
+	}
+	ident, ok := node.(*Identifier)
+	if !ok {
+		return nil, fmt.Errorf("ast: %T is not an identifier", node)
+	}
+	return ident, nil
+}
+
+func decodeBlock(data json.RawMessage) (*BlockStatement, error) {
+	node, err := UnmarshalNode(data)
+	if err != nil || node == nil {
+		return nil, err
+	}
+	block, ok := node.(*BlockStatement)
+	if !ok {
+		return nil, fmt.Errorf("ast: %T is not a block statement", node)
+	}
+	return block, nil
+}
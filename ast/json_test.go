@@ -0,0 +1,77 @@
+package ast_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/nishokbanand/interpreter/ast"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	program := parse(t, `let x = -1 + y * 2;
+	const limit = 10;
+	while (x < limit) { x += 1; if_x; break; }
+	for (i in xs) { continue; }
+	return (x = 5);`)
+	data, err := json.Marshal(program)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error %v", err)
+	}
+	var decoded ast.Program
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal returned error %v", err)
+	}
+	if !reflect.DeepEqual(program, &decoded) {
+		t.Fatalf("program did not round trip.\nexpected=%s\ngot=%s", program.String(), decoded.String())
+	}
+}
+
+func TestJSONEncoding(t *testing.T) {
+	program := parse(t, `-x;`)
+	data, err := json.Marshal(program)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error %v", err)
+	}
+	expected := `{"kind":"Program","statements":[{"kind":"ExpressionStatement",` +
+		`"token":{"type":"-","literal":"-","line":1,"column":1},` +
+		`"expression":{"kind":"PrefixExpression","token":{"type":"-","literal":"-","line":1,"column":1},"operator":"-",` +
+		`"right":{"kind":"Identifier","token":{"type":"IDENT","literal":"x","line":1,"column":2},"value":"x"}}}]}`
+	if string(data) != expected {
+		t.Fatalf("encoding wrong.\nexpected=%s\ngot=%s", expected, data)
+	}
+}
+
+func TestUnmarshalNode(t *testing.T) {
+	node, err := ast.UnmarshalNode([]byte(`{"kind":"IntegerLiteral","token":{"type":"INT","literal":"5","line":2,"column":3},"value":5}`))
+	if err != nil {
+		t.Fatalf("UnmarshalNode returned error %v", err)
+	}
+	literal, ok := node.(*ast.IntegerLiteral)
+	if !ok {
+		t.Fatalf("node is not IntegerLiteral instead we got %T", node)
+	}
+	if literal.Value != 5 || literal.Token.Line != 2 || literal.Token.Column != 3 {
+		t.Fatalf("literal wrong, got %+v", literal)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"kind":"Nope"}`, `ast: unknown node kind "Nope"`},
+		{`{"kind":"Program","statements":[{"kind":"Identifier","value":"x"}]}`, `ast: *ast.Identifier is not a statement`},
+		{`{"kind":"ExpressionStatement","expression":{"kind":"BreakStatement"}}`, `ast: *ast.BreakStatement is not an expression`},
+		{`{"kind":"LetStatement","name":{"kind":"IntegerLiteral","value":1}}`, `ast: *ast.IntegerLiteral is not an identifier`},
+		{`{"kind":"AssignExpression","name":{"kind":"BreakStatement"}}`, `ast: *ast.BreakStatement is not an identifier`},
+		{`{"kind":"ForStatement","variable":{"kind":"Identifier","value":"x"},"body":{"kind":"Program","statements":[]}}`, `ast: *ast.Program is not a block statement`},
+		{`{"kind":"WhileStatement","body":{"kind":"ExpressionStatement"}}`, `ast: *ast.ExpressionStatement is not a block statement`},
+		{`{"kind":"ForStatement","variable":{"value":"x"}}`, `ast: unknown node kind ""`},
+	}
+	for _, tt := range tests {
+		_, err := ast.UnmarshalNode([]byte(tt.input))
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("UnmarshalNode(%s) error wrong. expected=%q got=%v", tt.input, tt.expected, err)
+		}
+	}
+}
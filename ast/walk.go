@@ -0,0 +1,181 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A Visitor's Visit method is invoked for each node encountered by Walk. If
+// the result visitor w is not nil, Walk visits each of the children of node
+// with w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses an AST in depth-first order: it starts by calling
+// v.Visit(node); node must not be nil. If the visitor w returned by
+// v.Visit(node) is not nil, Walk is invoked recursively with visitor w for
+// each of the non-nil children of node, followed by a call of w.Visit(nil).
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+	switch n := node.(type) {
+	case *Program:
+		walkStatements(v, n.Statements)
+	case *LetStatement:
+		walkChild(v, n.Name)
+		walkChild(v, n.Value)
+	case *ReturnStatement:
+		walkChild(v, n.ReturnValue)
+	case *ExpressionStatement:
+		walkChild(v, n.Expression)
+	case *BlockStatement:
+		walkStatements(v, n.Statements)
+	case *WhileStatement:
+		walkChild(v, n.Condition)
+		walkChild(v, n.Body)
+	case *ForStatement:
+		walkChild(v, n.Variable)
+		walkChild(v, n.Iterable)
+		walkChild(v, n.Body)
+	case *PrefixExpression:
+		walkChild(v, n.Right)
+	case *InfixExpression:
+		walkChild(v, n.Left)
+		walkChild(v, n.Right)
+	case *AssignExpression:
+		walkChild(v, n.Name)
+		walkChild(v, n.Value)
+	case *Identifier, *IntegerLiteral, *BreakStatement, *ContinueStatement:
+		//leaves
+	default:
+		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", n))
+	}
+	v.Visit(nil)
+}
+
+func walkStatements(v Visitor, stmts []Statement) {
+	for _, stmt := range stmts {
+		walkChild(v, stmt)
+	}
+}
+
+// walkChild walks node unless it is missing, which happens in trees built
+// from input that failed to parse.
+func walkChild(v Visitor, node Node) {
+	if !isNil(node) {
+		Walk(v, node)
+	}
+}
+
+// isNil reports whether node is nil or a nil pointer stored in the interface.
+func isNil(node Node) bool {
+	if node == nil {
+		return true
+	}
+	value := reflect.ValueOf(node)
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses an AST in depth-first order: it starts by calling
+// f(node); node must not be nil. If f returns true, Inspect invokes f
+// recursively for each of the non-nil children of node, followed by a call
+// of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
+
+// Rewrite traverses an AST in depth-first order, replacing every node by the
+// result of f. Children are rewritten before their parent, so f sees a node
+// whose children have already been replaced. A nil result removes a statement
+// from its list and clears any other field; any other result must fit the
+// field it is stored in, or Rewrite panics.
+func Rewrite(node Node, f func(Node) Node) Node {
+	switch n := node.(type) {
+	case *Program:
+		n.Statements = rewriteStatements(n.Statements, f)
+	case *LetStatement:
+		n.Name = rewriteIdentifier(n.Name, f)
+		n.Value = rewriteExpression(n.Value, f)
+	case *ReturnStatement:
+		n.ReturnValue = rewriteExpression(n.ReturnValue, f)
+	case *ExpressionStatement:
+		n.Expression = rewriteExpression(n.Expression, f)
+	case *BlockStatement:
+		n.Statements = rewriteStatements(n.Statements, f)
+	case *WhileStatement:
+		n.Condition = rewriteExpression(n.Condition, f)
+		n.Body = rewriteBlock(n.Body, f)
+	case *ForStatement:
+		n.Variable = rewriteIdentifier(n.Variable, f)
+		n.Iterable = rewriteExpression(n.Iterable, f)
+		n.Body = rewriteBlock(n.Body, f)
+	case *PrefixExpression:
+		n.Right = rewriteExpression(n.Right, f)
+	case *InfixExpression:
+		n.Left = rewriteExpression(n.Left, f)
+		n.Right = rewriteExpression(n.Right, f)
+	case *AssignExpression:
+		n.Name = rewriteIdentifier(n.Name, f)
+		n.Value = rewriteExpression(n.Value, f)
+	case *Identifier, *IntegerLiteral, *BreakStatement, *ContinueStatement:
+		//leaves
+	default:
+		panic(fmt.Sprintf("ast.Rewrite: unexpected node type %T", n))
+	}
+	return f(node)
+}
+
+func rewriteStatements(stmts []Statement, f func(Node) Node) []Statement {
+	result := stmts[:0]
+	for _, stmt := range stmts {
+		if isNil(stmt) {
+			result = append(result, stmt)
+			continue
+		}
+		if node := Rewrite(stmt, f); node != nil {
+			result = append(result, node.(Statement))
+		}
+	}
+	return result
+}
+
+func rewriteExpression(exp Expression, f func(Node) Node) Expression {
+	if isNil(exp) {
+		return exp
+	}
+	if node := Rewrite(exp, f); node != nil {
+		return node.(Expression)
+	}
+	return nil
+}
+
+func rewriteIdentifier(ident *Identifier, f func(Node) Node) *Identifier {
+	if ident == nil {
+		return nil
+	}
+	if node := Rewrite(ident, f); node != nil {
+		return node.(*Identifier)
+	}
+	return nil
+}
+
+func rewriteBlock(block *BlockStatement, f func(Node) Node) *BlockStatement {
+	if block == nil {
+		return nil
+	}
+	if node := Rewrite(block, f); node != nil {
+		return node.(*BlockStatement)
+	}
+	return nil
+}
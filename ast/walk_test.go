@@ -0,0 +1,140 @@
+package ast_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/nishokbanand/interpreter/ast"
+	"github.com/nishokbanand/interpreter/format"
+	"github.com/nishokbanand/interpreter/lexer"
+	"github.com/nishokbanand/interpreter/parser"
+)
+
+func parse(t *testing.T, input string) *ast.Program {
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser has errors for %q: %v", input, p.Errors())
+	}
+	return program
+}
+
+func TestInspect(t *testing.T) {
+	program := parse(t, `let x = -1 + y; while (x) { x = 2; break; } for (i in xs) { continue; } return x;`)
+	var got []string
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node != nil {
+			got = append(got, fmt.Sprintf("%T", node))
+		}
+		return true
+	})
+	expected := []string{
+		"*ast.Program",
+		"*ast.LetStatement", "*ast.Identifier", "*ast.InfixExpression", "*ast.PrefixExpression", "*ast.IntegerLiteral", "*ast.Identifier",
+		"*ast.WhileStatement", "*ast.Identifier", "*ast.BlockStatement",
+		"*ast.ExpressionStatement", "*ast.AssignExpression", "*ast.Identifier", "*ast.IntegerLiteral",
+		"*ast.BreakStatement",
+		"*ast.ForStatement", "*ast.Identifier", "*ast.Identifier", "*ast.BlockStatement", "*ast.ContinueStatement",
+		"*ast.ReturnStatement", "*ast.Identifier",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Inspect order wrong.\nexpected=%v\ngot=%v", expected, got)
+	}
+}
+
+func TestInspectPrunes(t *testing.T) {
+	program := parse(t, `let x = 1 + 2; while (x) { y; }`)
+	var idents []string
+	ast.Inspect(program, func(node ast.Node) bool {
+		if _, ok := node.(*ast.WhileStatement); ok {
+			return false
+		}
+		if ident, ok := node.(*ast.Identifier); ok {
+			idents = append(idents, ident.Value)
+		}
+		return true
+	})
+	if !reflect.DeepEqual(idents, []string{"x"}) {
+		t.Fatalf("Inspect did not skip the while statement, got %v", idents)
+	}
+}
+
+type depthVisitor struct {
+	depth int
+	max   *int
+}
+
+func (v depthVisitor) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		return nil
+	}
+	if v.depth > *v.max {
+		*v.max = v.depth
+	}
+	return depthVisitor{depth: v.depth + 1, max: v.max}
+}
+
+func TestWalk(t *testing.T) {
+	program := parse(t, `1 + 2 * 3;`)
+	max := 0
+	ast.Walk(depthVisitor{max: &max}, program)
+	//Program, ExpressionStatement, +, *, 2
+	if max != 4 {
+		t.Fatalf("max depth is not 4 instead we got %d", max)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	program := parse(t, `let a = x + 1; break_me; while (x) { x; y; }`)
+	result := ast.Rewrite(program, func(node ast.Node) ast.Node {
+		switch node := node.(type) {
+		case *ast.Identifier:
+			if node.Value == "x" {
+				return &ast.Identifier{Token: node.Token, Value: "z"}
+			}
+		case *ast.ExpressionStatement:
+			if ident, ok := node.Expression.(*ast.Identifier); ok && ident.Value != "z" {
+				return nil
+			}
+		}
+		return node
+	})
+	expected := "let a = z + 1;\nwhile (z) {\n\tz;\n}\n"
+	if got := format.Program(result.(*ast.Program)); got != expected {
+		t.Fatalf("Rewrite wrong. expected=%q got=%q", expected, got)
+	}
+}
+
+func TestTraversalSkipsMissingNodes(t *testing.T) {
+	program := &ast.Program{Statements: []ast.Statement{
+		(*ast.WhileStatement)(nil),
+		&ast.WhileStatement{Condition: &ast.Identifier{Value: "x"}},
+		&ast.ForStatement{Iterable: &ast.Identifier{Value: "xs"}},
+		&ast.LetStatement{Value: &ast.IntegerLiteral{Value: 1}},
+		&ast.ExpressionStatement{Expression: &ast.InfixExpression{Left: &ast.IntegerLiteral{Value: 1}, Operator: "+"}},
+		&ast.BlockStatement{Statements: []ast.Statement{(*ast.LetStatement)(nil)}},
+	}}
+	visited := 0
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node != nil {
+			visited++
+		}
+		return true
+	})
+	//Program, 5 non-nil statements, x, xs, 1, infix, 1
+	if visited != 11 {
+		t.Fatalf("visited %d nodes instead of 11", visited)
+	}
+	rewritten := 0
+	ast.Rewrite(program, func(node ast.Node) ast.Node {
+		rewritten++
+		return node
+	})
+	if rewritten != visited {
+		t.Fatalf("rewrote %d nodes instead of %d", rewritten, visited)
+	}
+	if len(program.Statements) != 6 {
+		t.Fatalf("Rewrite changed the statements, got %d", len(program.Statements))
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nishokbanand/interpreter/format"
+)
+
+// fmtCommand implements "monkey fmt [-w | -check] [file...]", formatting stdin
+// to stdout when no files are given.
+func fmtCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	write := flags.Bool("w", false, "write the result back to the files")
+	check := flags.Bool("check", false, "list files whose formatting differs")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *write && *check {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		if *write {
+			fmt.Fprintln(stderr, "cannot use -w with stdin")
+			return exitUsage
+		}
+		src, err := io.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "could not read stdin: %v\n", err)
+			return exitUsage
+		}
+		return formatFile("<stdin>", src, *check, stdout, stderr)
+	}
+	code := exitOK
+	for _, path := range flags.Args() {
+		//like gofmt, a file that cannot be read does not stop the others
+		src, err := os.ReadFile(path)
+		var result int
+		switch {
+		case err != nil:
+			fmt.Fprintf(stderr, "could not read %s: %v\n", path, err)
+			result = exitUsage
+		case *write:
+			result = rewriteFile(path, src, stderr)
+		default:
+			result = formatFile(path, src, *check, stdout, stderr)
+		}
+		if result > code {
+			code = result
+		}
+	}
+	return code
+}
+
+// formatFile prints the formatted src, or with check only the name of the
+// file if it is not formatted.
+func formatFile(name string, src []byte, check bool, stdout, stderr io.Writer) int {
+	formatted, err := format.Source(src)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", name, err)
+		return exitParseError
+	}
+	if !check {
+		stdout.Write(formatted)
+		return exitOK
+	}
+	if !bytes.Equal(src, formatted) {
+		fmt.Fprintln(stdout, name)
+		return exitUnformatted
+	}
+	return exitOK
+}
+
+func rewriteFile(path string, src []byte, stderr io.Writer) int {
+	formatted, err := format.Source(src)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", path, err)
+		return exitParseError
+	}
+	if bytes.Equal(src, formatted) {
+		return exitOK
+	}
+	if err := os.WriteFile(path, formatted, 0644); err != nil {
+		fmt.Fprintf(stderr, "could not write %s: %v\n", path, err)
+		return exitUsage
+	}
+	return exitOK
+}
@@ -0,0 +1,128 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/nishokbanand/interpreter/ast"
+	"github.com/nishokbanand/interpreter/lexer"
+	"github.com/nishokbanand/interpreter/parser"
+)
+
+// Source parses src and returns it in canonical form.
+func Source(src []byte) ([]byte, error) {
+	p := parser.New(lexer.New(string(src)))
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) != 0 {
+		return nil, errors.New(strings.Join(errs, "\n"))
+	}
+	return []byte(Program(program)), nil
+}
+
+// Program renders program as source, one statement per line, with blocks
+// indented by tabs and only the parentheses the precedences require. Children
+// missing from a tree that failed to parse are left out.
+func Program(program *ast.Program) string {
+	var out bytes.Buffer
+	for _, stmt := range program.Statements {
+		writeStatement(&out, stmt, 0)
+	}
+	return out.String()
+}
+
+func writeStatement(out *bytes.Buffer, stmt ast.Statement, depth int) {
+	out.WriteString(strings.Repeat("\t", depth))
+	switch stmt := stmt.(type) {
+	case nil:
+		//a statement that failed to parse
+	case *ast.LetStatement:
+		out.WriteString(stmt.TokenLiteral() + " " + name(stmt.Name) + " = ")
+		out.WriteString(expression(stmt.Value, parser.LOWEST))
+		out.WriteString(";")
+	case *ast.ReturnStatement:
+		out.WriteString("return")
+		if stmt.ReturnValue != nil {
+			out.WriteString(" " + expression(stmt.ReturnValue, parser.LOWEST))
+		}
+		out.WriteString(";")
+	case *ast.ExpressionStatement:
+		out.WriteString(expression(stmt.Expression, parser.LOWEST) + ";")
+	case *ast.WhileStatement:
+		out.WriteString("while (" + expression(stmt.Condition, parser.LOWEST) + ") ")
+		writeBlock(out, stmt.Body, depth)
+	case *ast.ForStatement:
+		out.WriteString("for (" + name(stmt.Variable) + " in " + expression(stmt.Iterable, parser.LOWEST) + ") ")
+		writeBlock(out, stmt.Body, depth)
+	case *ast.BlockStatement:
+		writeBlock(out, stmt, depth)
+	case *ast.BreakStatement:
+		out.WriteString("break;")
+	case *ast.ContinueStatement:
+		out.WriteString("continue;")
+	default:
+		out.WriteString(stmt.String())
+	}
+	out.WriteString("\n")
+}
+
+func writeBlock(out *bytes.Buffer, block *ast.BlockStatement, depth int) {
+	out.WriteString("{\n")
+	if block == nil {
+		block = &ast.BlockStatement{}
+	}
+	for _, stmt := range block.Statements {
+		writeStatement(out, stmt, depth+1)
+	}
+	out.WriteString(strings.Repeat("\t", depth) + "}")
+}
+
+// expression renders exp, parenthesized if it binds less tightly than the
+// context it appears in.
+func expression(exp ast.Expression, context int) string {
+	var s string
+	switch exp := exp.(type) {
+	case nil:
+		return ""
+	case *ast.Identifier:
+		return exp.Value
+	case *ast.IntegerLiteral:
+		return exp.Token.Literal
+	case *ast.PrefixExpression:
+		return exp.Operator + expression(exp.Right, parser.PREFIX)
+	case *ast.InfixExpression:
+		precedence := parser.Precedence(exp.Token.Type)
+		//infix operators are left associative, so an equal right operand
+		//needs parentheses to keep its grouping
+		s = expression(exp.Left, precedence) + " " + exp.Operator + " " + expression(exp.Right, precedence+1)
+	case *ast.AssignExpression:
+		//assignment is right associative, so only the name is bound tighter
+		s = name(exp.Name) + " " + exp.Operator + " " + expression(exp.Value, parser.ASSIGN)
+	default:
+		return exp.String()
+	}
+	if precedence(exp) < context {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// name renders ident, which is missing if it failed to parse.
+func name(ident *ast.Identifier) string {
+	if ident == nil {
+		return ""
+	}
+	return ident.Value
+}
+
+func precedence(exp ast.Expression) int {
+	switch exp := exp.(type) {
+	case *ast.InfixExpression:
+		return parser.Precedence(exp.Token.Type)
+	case *ast.AssignExpression:
+		return parser.ASSIGN
+	case *ast.PrefixExpression:
+		return parser.PREFIX
+	}
+	return parser.CALL
+}
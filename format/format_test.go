@@ -0,0 +1,98 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/nishokbanand/interpreter/ast"
+	"github.com/nishokbanand/interpreter/lexer"
+	"github.com/nishokbanand/interpreter/parser"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x=5", "let x = 5;\n"},
+		{"const   limit = 10 ;", "const limit = 10;\n"},
+		{"return (1+2)*3", "return (1 + 2) * 3;\n"},
+		{"return", "return;\n"},
+		{"((1 + 2)) + 3;", "1 + 2 + 3;\n"},
+		{"1 + (2 + 3);", "1 + (2 + 3);\n"},
+		{"1 - (2 - 3);", "1 - (2 - 3);\n"},
+		{"(1 * 2) + (3 * 4);", "1 * 2 + 3 * 4;\n"},
+		{"-(a + b) * -c;", "-(a + b) * -c;\n"},
+		{"- -5;", "--5;\n"},
+		{"(a < b) == (c > d);", "a < b == c > d;\n"},
+		{"x = y = 1 + 2;", "x = y = 1 + 2;\n"},
+		{"x += (y * 2);", "x += y * 2;\n"},
+		{"while(x<10){x+=1;if_x;}", "while (x < 10) {\n\tx += 1;\n\tif_x;\n}\n"},
+		{"for (x in xs) { while (x) { break; } continue; }", "for (x in xs) {\n\twhile (x) {\n\t\tbreak;\n\t}\n\tcontinue;\n}\n"},
+		{"while (x) {}", "while (x) {\n}\n"},
+		{"let a = 1; let b = 2;", "let a = 1;\nlet b = 2;\n"},
+	}
+	for _, tt := range tests {
+		formatted, err := Source([]byte(tt.input))
+		if err != nil {
+			t.Errorf("Source(%q) returned error %v", tt.input, err)
+			continue
+		}
+		if string(formatted) != tt.expected {
+			t.Errorf("Source(%q) wrong. expected=%q got=%q", tt.input, tt.expected, formatted)
+			continue
+		}
+		if parsed(t, tt.input) != parsed(t, string(formatted)) {
+			t.Errorf("Source(%q) changed the program, got %q", tt.input, formatted)
+		}
+		again, err := Source(formatted)
+		if err != nil || string(again) != string(formatted) {
+			t.Errorf("Source is not idempotent for %q, got %q (%v)", formatted, again, err)
+		}
+	}
+}
+
+func TestProgramWithMissingNodes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1 + ;", "let x = 1 + ;\n"},
+		{"x = ;", "x = ;\n"},
+		{"1 + x = 5;", ";\n5;\n"},
+	}
+	for _, tt := range tests {
+		p := parser.New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Fatalf("%q parsed without errors", tt.input)
+		}
+		if got := Program(program); got != tt.expected {
+			t.Errorf("Program(%q) wrong. expected=%q got=%q", tt.input, tt.expected, got)
+		}
+	}
+	loop := &ast.Program{Statements: []ast.Statement{nil, &ast.ForStatement{}}}
+	if got := Program(loop); got != "\nfor ( in ) {\n}\n" {
+		t.Errorf("Program of an empty for loop wrong, got %q", got)
+	}
+}
+
+func TestSourceParseErrors(t *testing.T) {
+	_, err := Source([]byte("let = 5;"))
+	if err == nil {
+		t.Fatalf("expected a parse error")
+	}
+	expected := "expected next token to be IDENT but got = instead\nno PrefixParseFunc found for ="
+	if err.Error() != expected {
+		t.Fatalf("error wrong. expected=%q got=%q", expected, err.Error())
+	}
+}
+
+// parsed returns the fully parenthesized rendering of input's syntax tree.
+func parsed(t *testing.T, input string) string {
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser has errors for %q: %v", input, p.Errors())
+	}
+	return program.String()
+}
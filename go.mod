@@ -1,3 +1,7 @@
 module github.com/nishokbanand/interpreter
 
-go 1.22.1
+go 1.23.0
+
+require golang.org/x/term v0.32.0
+
+require golang.org/x/sys v0.33.0 // indirect
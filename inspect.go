@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nishokbanand/interpreter/ast"
+	"github.com/nishokbanand/interpreter/lexer"
+	"github.com/nishokbanand/interpreter/parser"
+	"github.com/nishokbanand/interpreter/repl"
+	"github.com/nishokbanand/interpreter/token"
+)
+
+// readSource reads the file named by args, or stdin when there is none.
+func readSource(args []string, stdin io.Reader) (string, error) {
+	if len(args) == 0 {
+		source, err := io.ReadAll(stdin)
+		return string(source), err
+	}
+	source, err := os.ReadFile(args[0])
+	return string(source), err
+}
+
+// tokensCommand implements "monkey tokens [file]".
+func tokensCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 1 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	source, err := readSource(args, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "could not read source: %v\n", err)
+		return exitUsage
+	}
+	l := lexer.New(source)
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		fmt.Fprintf(stdout, "%d:%d\t%s\t%q\n", tok.Line, tok.Column, tok.Type, tok.Literal)
+	}
+	return exitOK
+}
+
+// astCommand implements "monkey ast [-json] [file]".
+func astCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ast", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	asJSON := flags.Bool("json", false, "print the tree as JSON")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	source, err := readSource(flags.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "could not read source: %v\n", err)
+		return exitUsage
+	}
+	p := parser.New(lexer.New(source))
+	program := p.ParseProgram()
+	if errors := p.Errors(); len(errors) != 0 {
+		repl.PrintParserErrors(stderr, errors)
+		return exitParseError
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(program)
+		return exitOK
+	}
+	newTree("", program).print(stdout, 0)
+	return exitOK
+}
+
+// tree is a generic view of an ast.Node used for printing it.
+type tree struct {
+	// field is the name of the field holding the node in its parent.
+	field    string
+	node     string
+	value    string
+	line     int
+	column   int
+	children []*tree
+}
+
+func newTree(field string, node ast.Node) *tree {
+	t := &tree{field: field, node: strings.TrimPrefix(fmt.Sprintf("%T", node), "*ast.")}
+	var tok token.Token
+	switch node := node.(type) {
+	case *ast.Program:
+		for _, stmt := range node.Statements {
+			t.add("", stmt)
+		}
+	case *ast.LetStatement:
+		tok = node.Token
+		t.value = node.Token.Literal
+		t.add("Name", node.Name)
+		t.add("Value", node.Value)
+	case *ast.ReturnStatement:
+		tok = node.Token
+		t.add("ReturnValue", node.ReturnValue)
+	case *ast.ExpressionStatement:
+		tok = node.Token
+		t.add("Expression", node.Expression)
+	case *ast.BlockStatement:
+		tok = node.Token
+		for _, stmt := range node.Statements {
+			t.add("", stmt)
+		}
+	case *ast.WhileStatement:
+		tok = node.Token
+		t.add("Condition", node.Condition)
+		t.add("Body", node.Body)
+	case *ast.ForStatement:
+		tok = node.Token
+		t.add("Variable", node.Variable)
+		t.add("Iterable", node.Iterable)
+		t.add("Body", node.Body)
+	case *ast.BreakStatement:
+		tok = node.Token
+	case *ast.ContinueStatement:
+		tok = node.Token
+	case *ast.Identifier:
+		tok = node.Token
+		t.value = node.Value
+	case *ast.IntegerLiteral:
+		tok = node.Token
+		t.value = node.Token.Literal
+	case *ast.PrefixExpression:
+		tok = node.Token
+		t.value = node.Operator
+		t.add("Right", node.Right)
+	case *ast.InfixExpression:
+		tok = node.Token
+		t.value = node.Operator
+		t.add("Left", node.Left)
+		t.add("Right", node.Right)
+	case *ast.AssignExpression:
+		tok = node.Token
+		t.value = node.Operator
+		t.add("Name", node.Name)
+		t.add("Value", node.Value)
+	}
+	t.line = tok.Line
+	t.column = tok.Column
+	return t
+}
+
+func (t *tree) add(field string, node ast.Node) {
+	if node == nil {
+		return
+	}
+	t.children = append(t.children, newTree(field, node))
+}
+
+func (t *tree) print(out io.Writer, depth int) {
+	line := strings.Repeat("  ", depth)
+	if t.field != "" {
+		line += t.field + ": "
+	}
+	line += t.node
+	if t.value != "" {
+		line += fmt.Sprintf(" %q", t.value)
+	}
+	if t.line != 0 {
+		line += fmt.Sprintf(" %d:%d", t.line, t.column)
+	}
+	fmt.Fprintln(out, line)
+	for _, child := range t.children {
+		child.print(out, depth+1)
+	}
+}
@@ -9,16 +9,24 @@ type Lexer struct {
 	position     int
 	readPosition int
 	ch           byte
+	//position of ch in lines and columns
+	line   int
+	column int
 }
 
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	l := &Lexer{input: input, line: 1}
 	// fmt.Println(l)
 	l.readChar()
 	return l
 }
 
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
+	l.column++
 	if l.readPosition >= len(l.input) {
 		l.ch = 0
 	} else {
@@ -29,8 +37,16 @@ func (l *Lexer) readChar() {
 }
 
 func (l *Lexer) NextToken() token.Token {
-	var tok token.Token
 	l.skipWhitespace()
+	line, column := l.line, l.column
+	tok := l.readToken()
+	tok.Line = line
+	tok.Column = column
+	return tok
+}
+
+func (l *Lexer) readToken() token.Token {
+	var tok token.Token
 	switch l.ch {
 	case '=':
 		if l.peekChar() == '=' {
@@ -42,7 +58,7 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.ASSIGN, l.ch)
 		}
 	case '+':
-		tok = newToken(token.PLUS, l.ch)
+		tok = l.newAssignToken(token.PLUS, token.PLUSASSIGN)
 	case ';':
 		tok = newToken(token.SEMICOLON, l.ch)
 	case '{':
@@ -64,11 +80,11 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.NOT, l.ch)
 		}
 	case '-':
-		tok = newToken(token.MINUS, l.ch)
+		tok = l.newAssignToken(token.MINUS, token.MINUSASSIGN)
 	case '/':
-		tok = newToken(token.FRWDSLASH, l.ch)
+		tok = l.newAssignToken(token.FRWDSLASH, token.FRWDSLASHASSIGN)
 	case '*':
-		tok = newToken(token.ASTERISK, l.ch)
+		tok = l.newAssignToken(token.ASTERISK, token.ASTERISKASSIGN)
 	case '<':
 		tok = newToken(token.LESSTHAN, l.ch)
 	case '>':
@@ -93,6 +109,17 @@ func (l *Lexer) NextToken() token.Token {
 	return tok
 }
 
+// newAssignToken returns the compound assignment token when the operator is
+// followed by '=' and the plain operator token otherwise.
+func (l *Lexer) newAssignToken(operator, assign token.TokenType) token.Token {
+	if l.peekChar() == '=' {
+		ch := l.ch
+		l.readChar()
+		return token.Token{Type: assign, Literal: string(ch) + string(l.ch)}
+	}
+	return newToken(operator, l.ch)
+}
+
 func newToken(tokenType token.TokenType, ch byte) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch)}
 }
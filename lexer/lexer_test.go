@@ -116,3 +116,73 @@ func TestNextToken(t *testing.T) {
 		}
 	}
 }
+
+func TestAssignmentOperators(t *testing.T) {
+	input := `x = 1; x += 2; x -= 3; x *= 4; x /= 5;`
+	tests := []struct {
+		expectedTokenType token.TokenType
+		expctedLiteral    string
+	}{
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "1"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.PLUSASSIGN, "+="},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.MINUSASSIGN, "-="},
+		{token.INT, "3"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.ASTERISKASSIGN, "*="},
+		{token.INT, "4"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.FRWDSLASHASSIGN, "/="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedTokenType {
+			t.Fatalf("tests[%d] - tokenType wrong. expected=%q got=%q", i, tt.expectedTokenType, tok.Type)
+		}
+		if tok.Literal != tt.expctedLiteral {
+			t.Fatalf("tests[%d] - Literal wrong. expected=%q got=%q", i, tt.expctedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	input := "let x = 5;\n  x +=\n\t10;"
+	tests := []struct {
+		expectedTokenType token.TokenType
+		expectedLine      int
+		expectedColumn    int
+	}{
+		{token.LET, 1, 1},
+		{token.IDENT, 1, 5},
+		{token.ASSIGN, 1, 7},
+		{token.INT, 1, 9},
+		{token.SEMICOLON, 1, 10},
+		{token.IDENT, 2, 3},
+		{token.PLUSASSIGN, 2, 5},
+		{token.INT, 3, 2},
+		{token.SEMICOLON, 3, 4},
+		{token.EOF, 3, 5},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedTokenType {
+			t.Fatalf("tests[%d] - tokenType wrong. expected=%q got=%q", i, tt.expectedTokenType, tok.Type)
+		}
+		if tok.Line != tt.expectedLine || tok.Column != tt.expectedColumn {
+			t.Fatalf("tests[%d] - position wrong. expected=%d:%d got=%d:%d", i, tt.expectedLine, tt.expectedColumn, tok.Line, tok.Column)
+		}
+	}
+}
@@ -1,18 +1,128 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 
+	"github.com/nishokbanand/interpreter/lexer"
+	"github.com/nishokbanand/interpreter/parser"
 	"github.com/nishokbanand/interpreter/repl"
+	"golang.org/x/term"
 )
 
+// exit codes
+const (
+	exitOK          = 0
+	exitUnformatted = 1
+	exitUsage       = 2
+	exitParseError  = 3
+)
+
+const usage = `usage:
+	monkey [--quiet]            start the REPL, or run stdin when it is piped
+	monkey run file [args...]   run a script file
+	monkey -e source            run source given on the command line
+	monkey tokens [file]        print the tokens of file or stdin
+	monkey ast [-json] [file]   print the syntax tree of file or stdin
+	monkey fmt [-w | -check] [file...]
+	                            format files, or stdin to stdout
+`
+
 func main() {
-	user, err := user.Current()
-	if err != nil {
-		panic(err)
+	os.Exit(cli(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func cli(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("monkey", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	quiet := flags.Bool("quiet", false, "do not print the REPL greeting")
+	eval := flags.String("e", "", "source to run")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	args = flags.Args()
+	if isFlagSet(flags, "e") {
+		if len(args) != 0 {
+			fmt.Fprint(stderr, usage)
+			return exitUsage
+		}
+		return runSource(*eval, stderr)
+	}
+	if len(args) == 0 {
+		if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+			if !*quiet {
+				fmt.Fprint(stdout, greeting())
+			}
+			repl.Start(stdin, stdout)
+			return exitOK
+		}
+		source, err := io.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "could not read stdin: %v\n", err)
+			return exitUsage
+		}
+		return runSource(string(source), stderr)
+	}
+	switch args[0] {
+	case "run":
+		//the arguments after the file are reserved for the script
+		if len(args) < 2 {
+			fmt.Fprint(stderr, usage)
+			return exitUsage
+		}
+		source, err := os.ReadFile(args[1])
+		if err != nil {
+			fmt.Fprintf(stderr, "could not read %s: %v\n", args[1], err)
+			return exitUsage
+		}
+		return runSource(string(source), stderr)
+	case "tokens":
+		return tokensCommand(args[1:], stdin, stdout, stderr)
+	case "ast":
+		return astCommand(args[1:], stdin, stdout, stderr)
+	case "fmt":
+		return fmtCommand(args[1:], stdin, stdout, stderr)
+	default:
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+}
+
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// currentUser looks up the user to greet; tests replace it.
+var currentUser = user.Current
+
+// greeting addresses the user by name when it can be looked up, which fails
+// in minimal containers without a passwd entry.
+func greeting() string {
+	u, err := currentUser()
+	if err != nil || u.Username == "" {
+		return "Welcome to the language of the GODS\n"
+	}
+	return fmt.Sprintf("Welcome to the language of the GODS, Mr.%v\n", u.Username)
+}
+
+// runSource parses source, reporting parser errors on stderr. There is no
+// evaluator yet, so a program that parses produces no output.
+func runSource(source string, stderr io.Writer) int {
+	p := parser.New(lexer.New(source))
+	p.ParseProgram()
+	if errors := p.Errors(); len(errors) != 0 {
+		repl.PrintParserErrors(stderr, errors)
+		return exitParseError
 	}
-	fmt.Printf("Welcome to the language of the GODS, Mr.%v\n", user.Username)
-	repl.Start(os.Stdin, os.Stdout)
+	return exitOK
 }
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nishokbanand/interpreter/ast"
+)
+
+func TestCli(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "script.mk")
+	if err := os.WriteFile(script, []byte("let x = 1 + 2;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{"run file", []string{"run", script, "a", "b"}, "", exitOK, "", ""},
+		{"eval", []string{"-e", "5 * 5"}, "", exitOK, "", ""},
+		{"piped stdin", nil, "return 1;", exitOK, "", ""},
+		{"parse error", []string{"-e", "let = 1;"}, "", exitParseError, "", "parser errors:\n\texpected next token to be IDENT but got = instead\n\tno PrefixParseFunc found for =\n"},
+		{"missing file", []string{"run"}, "", exitUsage, "", usage},
+		{"unknown command", []string{"build"}, "", exitUsage, "", usage},
+		{"quiet piped stdin", []string{"--quiet"}, "x;", exitOK, "", ""},
+		{"quiet eval", []string{"-quiet", "-e", "x"}, "", exitOK, "", ""},
+		{"eval with extra args", []string{"-e", "x", "y"}, "", exitUsage, "", usage},
+		{"unknown flag", []string{"--loud"}, "", exitUsage, "", "flag provided but not defined: -loud\n" + usage},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		code := cli(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+		if code != tt.code {
+			t.Errorf("%s: exit code wrong. expected=%d got=%d", tt.name, tt.code, code)
+		}
+		if stdout.String() != tt.stdout {
+			t.Errorf("%s: stdout wrong. expected=%q got=%q", tt.name, tt.stdout, stdout.String())
+		}
+		if stderr.String() != tt.stderr {
+			t.Errorf("%s: stderr wrong. expected=%q got=%q", tt.name, tt.stderr, stderr.String())
+		}
+	}
+}
+
+func TestGreeting(t *testing.T) {
+	defer func(lookup func() (*user.User, error)) { currentUser = lookup }(currentUser)
+
+	tests := []struct {
+		name     string
+		user     *user.User
+		err      error
+		expected string
+	}{
+		{"named", &user.User{Username: "gopher"}, nil, "Welcome to the language of the GODS, Mr.gopher\n"},
+		{"lookup error", nil, errors.New("no passwd entry"), "Welcome to the language of the GODS\n"},
+		{"empty username", &user.User{}, nil, "Welcome to the language of the GODS\n"},
+	}
+	for _, tt := range tests {
+		currentUser = func() (*user.User, error) { return tt.user, tt.err }
+		if got := greeting(); got != tt.expected {
+			t.Errorf("%s: greeting wrong. expected=%q got=%q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestInspectCommands(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+	}{
+		{"tokens", []string{"tokens"}, "let x =\n  5;", exitOK, "1:1\tLET\t\"let\"\n1:5\tIDENT\t\"x\"\n1:7\t=\t\"=\"\n2:3\tINT\t\"5\"\n2:4\t;\t\";\"\n"},
+		{"ast", []string{"ast"}, "x = -1;", exitOK, "Program\n  ExpressionStatement 1:1\n    Expression: AssignExpression \"=\" 1:3\n      Name: Identifier \"x\" 1:1\n      Value: PrefixExpression \"-\" 1:5\n        Right: IntegerLiteral \"1\" 1:6\n"},
+		{"ast parse error", []string{"ast"}, "let;", exitParseError, ""},
+		{"ast too many files", []string{"ast", "a", "b"}, "", exitUsage, ""},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		code := cli(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+		if code != tt.code {
+			t.Errorf("%s: exit code wrong. expected=%d got=%d", tt.name, tt.code, code)
+		}
+		if stdout.String() != tt.stdout {
+			t.Errorf("%s: stdout wrong. expected=%q got=%q", tt.name, tt.stdout, stdout.String())
+		}
+	}
+}
+
+func TestAstJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := cli([]string{"ast", "-json"}, strings.NewReader("return 1 + 2;"), &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("exit code wrong. expected=%d got=%d, stderr %q", exitOK, code, stderr.String())
+	}
+	var program ast.Program
+	if err := json.Unmarshal(stdout.Bytes(), &program); err != nil {
+		t.Fatalf("output is not an encoded program: %v", err)
+	}
+	if program.String() != "return (1 + 2);" {
+		t.Fatalf("decoded program wrong, got %q", program.String())
+	}
+}
+
+func TestFmtCommand(t *testing.T) {
+	dir := t.TempDir()
+	messy := filepath.Join(dir, "messy.mk")
+	clean := filepath.Join(dir, "clean.mk")
+	missing := filepath.Join(dir, "missing.mk")
+	if err := os.WriteFile(messy, []byte("let x=(1+2)*3"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(clean, []byte("let x = 1;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+	}{
+		{"stdin", []string{"fmt"}, "x+=1", exitOK, "x += 1;\n"},
+		{"check", []string{"fmt", "-check", messy, clean}, "", exitUnformatted, messy + "\n"},
+		{"missing file", []string{"fmt", "-check", missing, messy}, "", exitUsage, messy + "\n"},
+		{"write", []string{"fmt", "-w", messy, clean}, "", exitOK, ""},
+		{"check after write", []string{"fmt", "--check", messy, clean}, "", exitOK, ""},
+		{"parse error", []string{"fmt"}, "let = 1;", exitParseError, ""},
+		{"write stdin", []string{"fmt", "-w"}, "", exitUsage, ""},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		code := cli(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+		if code != tt.code {
+			t.Errorf("%s: exit code wrong. expected=%d got=%d", tt.name, tt.code, code)
+		}
+		if stdout.String() != tt.stdout {
+			t.Errorf("%s: stdout wrong. expected=%q got=%q", tt.name, tt.stdout, stdout.String())
+		}
+	}
+	data, err := os.ReadFile(messy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "let x = (1 + 2) * 3;\n" {
+		t.Fatalf("file was not rewritten, got %q", data)
+	}
+}
@@ -0,0 +1,66 @@
+package optimize
+
+import (
+	"strconv"
+
+	"github.com/nishokbanand/interpreter/ast"
+	"github.com/nishokbanand/interpreter/token"
+)
+
+// Program folds constant expressions in place and returns the program.
+// Folded literals keep the token of the expression they replace.
+func Program(program *ast.Program) *ast.Program {
+	return ast.Rewrite(program, fold).(*ast.Program)
+}
+
+// fold folds a single node, whose children have already been folded.
+func fold(node ast.Node) ast.Node {
+	switch node := node.(type) {
+	case *ast.PrefixExpression:
+		return foldPrefix(node)
+	case *ast.InfixExpression:
+		return foldInfix(node)
+	}
+	return node
+}
+
+func foldPrefix(exp *ast.PrefixExpression) ast.Expression {
+	right, ok := exp.Right.(*ast.IntegerLiteral)
+	if !ok || exp.Operator != "-" {
+		return exp
+	}
+	return newInteger(exp.Token, -right.Value)
+}
+
+func foldInfix(exp *ast.InfixExpression) ast.Expression {
+	left, ok := exp.Left.(*ast.IntegerLiteral)
+	if !ok {
+		return exp
+	}
+	right, ok := exp.Right.(*ast.IntegerLiteral)
+	if !ok {
+		return exp
+	}
+	switch exp.Operator {
+	case "+":
+		return newInteger(left.Token, left.Value+right.Value)
+	case "-":
+		return newInteger(left.Token, left.Value-right.Value)
+	case "*":
+		return newInteger(left.Token, left.Value*right.Value)
+	case "/":
+		//leave division by zero for the runtime to report
+		if right.Value == 0 {
+			return exp
+		}
+		return newInteger(left.Token, left.Value/right.Value)
+	}
+	return exp
+}
+
+func newInteger(from token.Token, value int64) *ast.IntegerLiteral {
+	tok := from
+	tok.Type = token.INT
+	tok.Literal = strconv.FormatInt(value, 10)
+	return &ast.IntegerLiteral{Token: tok, Value: value}
+}
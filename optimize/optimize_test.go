@@ -0,0 +1,70 @@
+package optimize
+
+import (
+	"testing"
+
+	"github.com/nishokbanand/interpreter/ast"
+	"github.com/nishokbanand/interpreter/lexer"
+	"github.com/nishokbanand/interpreter/parser"
+)
+
+func TestConstantFolding(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"60 * 60 * 24;", "86400;"},
+		{"1 + 2 * 3;", "7;"},
+		{"-5 * 2;", "-10;"},
+		{"10 - -5;", "15;"},
+		{"7 / 2;", "3;"},
+		{"let day = 60 * 60 * 24;", "let day = 86400;"},
+		{"return 2 * 3;", "return 6;"},
+		{"x + 2 * 3;", "(x + 6);"},
+		{"2 * 3 + x;", "(6 + x);"},
+		{"x * 2 * 3;", "((x * 2) * 3);"},
+		{"x += 60 * 60;", "(x += 3600);"},
+		{"5 / 0;", "(5 / 0);"},
+		{"1 < 2;", "(1 < 2);"},
+		{"!5;", "(!5);"},
+		{"while (x < 2 * 3) { let y = 4 * 4; }", "while ((x < 6)) { let y = 16; }"},
+		{"for (x in 1 + 1) { x * 2 * 3; }", "for (x in 2) { ((x * 2) * 3); }"},
+	}
+	for _, tt := range tests {
+		program := parse(t, tt.input)
+		optimized := Program(program)
+		if optimized.String() != tt.expected {
+			t.Errorf("Program(%q) wrong. expected=%q got=%q", tt.input, tt.expected, optimized.String())
+		}
+	}
+}
+
+func TestFoldingKeepsToken(t *testing.T) {
+	program := Program(parse(t, "-2 * 3;"))
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ExpressionStatement instead we got %T", program.Statements[0])
+	}
+	literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression is not IntegerLiteral instead we got %T", stmt.Expression)
+	}
+	if literal.Value != -6 {
+		t.Fatalf("literal.Value is not -6 instead we got %d", literal.Value)
+	}
+	if literal.TokenLiteral() != "-6" {
+		t.Fatalf("literal.TokenLiteral() is not -6 instead we got %q", literal.TokenLiteral())
+	}
+	if literal.Token.Line != 1 || literal.Token.Column != 1 {
+		t.Fatalf("literal position is not 1:1 instead we got %d:%d", literal.Token.Line, literal.Token.Column)
+	}
+}
+
+func parse(t *testing.T, input string) *ast.Program {
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("parser has errors for %q: %v", input, p.Errors())
+	}
+	return program
+}
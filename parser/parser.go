@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/nishokbanand/interpreter/ast"
@@ -16,15 +17,18 @@ type Parser struct {
 	errors         []string
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
+	loopDepth      int
+	constants      map[string]bool
 }
 
 func New(l *lexer.Lexer) *Parser {
-	p := &Parser{l: l, errors: []string{}}
+	p := &Parser{l: l, errors: []string{}, constants: map[string]bool{}}
 	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	p.registerPrefix(token.IDENT, p.parseIdentifier)
 	p.registerPrefix(token.INT, p.parseInteger)
 	p.registerPrefix(token.NOT, p.parsePrefix)
 	p.registerPrefix(token.MINUS, p.parsePrefix)
+	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
 
 	p.infixParseFns = make(map[token.TokenType]infixParseFn)
 	p.registerInfix(token.PLUS, p.parseInfix)
@@ -35,6 +39,11 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.GREATERTHAN, p.parseInfix)
 	p.registerInfix(token.ASTERISK, p.parseInfix)
 	p.registerInfix(token.FRWDSLASH, p.parseInfix)
+	p.registerInfix(token.ASSIGN, p.parseAssign)
+	p.registerInfix(token.PLUSASSIGN, p.parseAssign)
+	p.registerInfix(token.MINUSASSIGN, p.parseAssign)
+	p.registerInfix(token.ASTERISKASSIGN, p.parseAssign)
+	p.registerInfix(token.FRWDSLASHASSIGN, p.parseAssign)
 	p.nextToken()
 	p.nextToken()
 	return p
@@ -63,8 +72,16 @@ func (p *Parser) parsePrefix() ast.Expression {
 	return exp
 }
 
+func (p *Parser) parseGroupedExpression() ast.Expression {
+	p.nextToken()
+	exp := p.parseExpression(LOWEST)
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	return exp
+}
+
 func (p *Parser) parseInfix(left ast.Expression) ast.Expression {
-	fmt.Println(left)
 	exp := &ast.InfixExpression{Token: p.currToken, Operator: p.currToken.Literal, Left: left}
 	precedence := p.currPrecedence()
 	p.nextToken()
@@ -72,12 +89,27 @@ func (p *Parser) parseInfix(left ast.Expression) ast.Expression {
 	return exp
 }
 
+func (p *Parser) parseAssign(left ast.Expression) ast.Expression {
+	name, ok := left.(*ast.Identifier)
+	if !ok {
+		msg := fmt.Sprintf("cannot assign to %s", left)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+	p.checkNotConstant(name)
+	exp := &ast.AssignExpression{Token: p.currToken, Name: name, Operator: p.currToken.Literal}
+	p.nextToken()
+	//assignment is right associative so x = y = 5 assigns y first
+	exp.Value = p.parseExpression(LOWEST)
+	return exp
+}
+
 func (p *Parser) nextToken() {
 	p.currToken = p.peekToken
 	p.peekToken = p.l.NextToken()
 }
 
-func (p *Parser) parseProgram() *ast.Program {
+func (p *Parser) ParseProgram() *ast.Program {
 	program := &ast.Program{}
 	program.Statements = []ast.Statement{}
 	for p.currToken.Type != token.EOF {
@@ -90,33 +122,81 @@ func (p *Parser) parseProgram() *ast.Program {
 	return program
 }
 
+// parseStatement returns an untyped nil when the statement fails to parse, so
+// callers can drop it with a plain nil check.
 func (p *Parser) parseStatement() ast.Statement {
 	switch p.currToken.Type {
-	case token.LET:
-		return p.parseLetStatement()
+	case token.LET, token.CONST:
+		if stmt := p.parseLetStatement(); stmt != nil {
+			return stmt
+		}
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.WHILE:
+		if stmt := p.parseWhileStatement(); stmt != nil {
+			return stmt
+		}
+	case token.FOR:
+		if stmt := p.parseForStatement(); stmt != nil {
+			return stmt
+		}
+	case token.BREAK:
+		return p.parseBreakStatement()
+	case token.CONTINUE:
+		return p.parseContinueStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
+	return nil
 }
 
 func (p *Parser) parseLetStatement() *ast.LetStatement {
-	stmt := &ast.LetStatement{Token: p.currToken}
+	stmt := &ast.LetStatement{Token: p.currToken, Constant: p.currTokenIs(token.CONST)}
 	if !p.expectPeek(token.IDENT) {
 		return nil
 	}
 	stmt.Name = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+	p.checkNotConstant(stmt.Name)
 	if !p.expectPeek(token.ASSIGN) {
 		return nil
 	}
-	//skipping expressions for now
-	for !p.currTokenIs(token.SEMICOLON) {
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
+	if stmt.Constant {
+		p.constants[stmt.Name.Value] = true
+	}
 	return stmt
 }
 
+// DeclareConstants marks names as constants bound by earlier input, such as
+// previous lines of a REPL session, so they cannot be rebound.
+func (p *Parser) DeclareConstants(names ...string) {
+	for _, name := range names {
+		p.constants[name] = true
+	}
+}
+
+// Constants returns the names of all constants known to the parser, in
+// sorted order.
+func (p *Parser) Constants() []string {
+	names := make([]string, 0, len(p.constants))
+	for name := range p.constants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *Parser) checkNotConstant(name *ast.Identifier) {
+	if p.constants[name.Value] {
+		msg := fmt.Sprintf("cannot reassign constant %s", name.Value)
+		p.errors = append(p.errors, msg)
+	}
+}
+
 func (p *Parser) expectPeek(toktype token.TokenType) bool {
 	if p.peekTokenIs(toktype) {
 		p.nextToken()
@@ -144,8 +224,103 @@ func (p *Parser) peekErrors(t token.TokenType) {
 
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	stmt := &ast.ReturnStatement{Token: p.currToken}
+	//a bare return has no value
+	if !p.peekTokenIs(token.SEMICOLON) && !p.peekTokenIs(token.EOF) {
+		p.nextToken()
+		stmt.ReturnValue = p.parseExpression(LOWEST)
+	}
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) parseBlockStatement() *ast.BlockStatement {
+	block := &ast.BlockStatement{Token: p.currToken}
+	block.Statements = []ast.Statement{}
 	p.nextToken()
-	for !p.currTokenIs(token.SEMICOLON) {
+	for !p.currTokenIs(token.RBRACE) && !p.currTokenIs(token.EOF) {
+		stmt := p.parseStatement()
+		if stmt != nil {
+			block.Statements = append(block.Statements, stmt)
+		}
+		p.nextToken()
+	}
+	if p.currTokenIs(token.EOF) {
+		msg := fmt.Sprintf("expected next token to be %s but got %s instead", token.RBRACE, token.EOF)
+		p.errors = append(p.errors, msg)
+	}
+	return block
+}
+
+func (p *Parser) parseLoopBody() *ast.BlockStatement {
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	p.loopDepth++
+	defer func() { p.loopDepth-- }()
+	return p.parseBlockStatement()
+}
+
+func (p *Parser) parseWhileStatement() *ast.WhileStatement {
+	stmt := &ast.WhileStatement{Token: p.currToken}
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	p.nextToken()
+	stmt.Condition = p.parseExpression(LOWEST)
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	stmt.Body = p.parseLoopBody()
+	if stmt.Body == nil {
+		return nil
+	}
+	return stmt
+}
+
+func (p *Parser) parseForStatement() *ast.ForStatement {
+	stmt := &ast.ForStatement{Token: p.currToken}
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Variable = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+	p.checkNotConstant(stmt.Variable)
+	if !p.expectPeek(token.IN) {
+		return nil
+	}
+	p.nextToken()
+	stmt.Iterable = p.parseExpression(LOWEST)
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	stmt.Body = p.parseLoopBody()
+	if stmt.Body == nil {
+		return nil
+	}
+	return stmt
+}
+
+func (p *Parser) parseBreakStatement() *ast.BreakStatement {
+	stmt := &ast.BreakStatement{Token: p.currToken}
+	if p.loopDepth == 0 {
+		p.errors = append(p.errors, "break outside of a loop")
+	}
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) parseContinueStatement() *ast.ContinueStatement {
+	stmt := &ast.ContinueStatement{Token: p.currToken}
+	if p.loopDepth == 0 {
+		p.errors = append(p.errors, "continue outside of a loop")
+	}
+	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
 	return stmt
@@ -176,6 +351,7 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN
 	EQUALS
 	LESSGREATER
 	SUM
@@ -185,14 +361,28 @@ const (
 )
 
 var precedences = map[token.TokenType]int{
-	token.EQUALS:      EQUALS,
-	token.NOTEQUALS:   EQUALS,
-	token.LESSTHAN:    LESSGREATER,
-	token.GREATERTHAN: LESSGREATER,
-	token.PLUS:        SUM,
-	token.MINUS:       SUM,
-	token.FRWDSLASH:   PRODUCT,
-	token.ASTERISK:    PRODUCT,
+	token.ASSIGN:          ASSIGN,
+	token.PLUSASSIGN:      ASSIGN,
+	token.MINUSASSIGN:     ASSIGN,
+	token.ASTERISKASSIGN:  ASSIGN,
+	token.FRWDSLASHASSIGN: ASSIGN,
+	token.EQUALS:          EQUALS,
+	token.NOTEQUALS:       EQUALS,
+	token.LESSTHAN:        LESSGREATER,
+	token.GREATERTHAN:     LESSGREATER,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.FRWDSLASH:       PRODUCT,
+	token.ASTERISK:        PRODUCT,
+}
+
+// Precedence returns how tightly an infix operator of the given token type
+// binds, or LOWEST for tokens that are not infix operators.
+func Precedence(tokenType token.TokenType) int {
+	if p, ok := precedences[tokenType]; ok {
+		return p
+	}
+	return LOWEST
 }
 
 func (p *Parser) peekPrecedence() int {
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/nishokbanand/interpreter/ast"
@@ -16,7 +17,7 @@ func TestLetStatements(t *testing.T) {
 	`
 	l := lexer.New(input)
 	p := New(l)
-	program := p.parseProgram()
+	program := p.ParseProgram()
 	checkParseErrors(t, p)
 	if program == nil {
 		t.Fatalf("the Parseprogram() is nil")
@@ -27,16 +28,20 @@ func TestLetStatements(t *testing.T) {
 
 	tests := []struct {
 		expectedIdentifer string
+		expectedValue     int64
 	}{
-		{"x"},
-		{"y"},
-		{"foobar"},
+		{"x", 5},
+		{"y", 10},
+		{"foobar", 100},
 	}
 	for i, tt := range tests {
 		stmt := program.Statements[i]
 		if !testLetStatement(t, stmt, tt.expectedIdentifer) {
 			return
 		}
+		if !testIntegerLiteral(t, stmt.(*ast.LetStatement).Value, tt.expectedValue) {
+			return
+		}
 	}
 }
 
@@ -78,16 +83,17 @@ func TestReturnStatements(t *testing.T) {
 	return 5;
 	return 10;
 	return 99322;
+	return;
 	`
 	l := lexer.New(input)
 	p := New(l)
+	program := p.ParseProgram()
 	checkParseErrors(t, p)
-	program := p.parseProgram()
 	if program == nil {
 		t.Errorf("ParseProgram returned nil")
 	}
-	if len(program.Statements) != 3 {
-		t.Errorf("the len of statements is not 3 but got %d", len(program.Statements))
+	if len(program.Statements) != 4 {
+		t.Errorf("the len of statements is not 4 but got %d", len(program.Statements))
 	}
 	for _, stmt := range program.Statements {
 		returnstmt, ok := stmt.(*ast.ReturnStatement)
@@ -99,13 +105,16 @@ func TestReturnStatements(t *testing.T) {
 			t.Errorf("returnstmt.TokenLiteral() not return instead %q", returnstmt.TokenLiteral())
 		}
 	}
+	if bare, ok := program.Statements[3].(*ast.ReturnStatement); ok && bare.ReturnValue != nil {
+		t.Errorf("bare return has a value, got %s", bare.ReturnValue.String())
+	}
 }
 
 func TestIdentifierExpression(t *testing.T) {
 	input := ` foobar;`
 	l := lexer.New(input)
 	p := New(l)
-	program := p.parseProgram()
+	program := p.ParseProgram()
 	checkParseErrors(t, p)
 	if program == nil {
 		t.Errorf("parseProgram returned nil")
@@ -134,7 +143,7 @@ func TestIntegerLiteral(t *testing.T) {
 	input := ` 5;`
 	l := lexer.New(input)
 	p := New(l)
-	program := p.parseProgram()
+	program := p.ParseProgram()
 	checkParseErrors(t, p)
 	if program == nil {
 		t.Errorf("parseProgram returned nil")
@@ -171,7 +180,7 @@ func TestPrefixOperators(t *testing.T) {
 	for _, tt := range tests {
 		l := lexer.New(tt.input)
 		p := New(l)
-		program := p.parseProgram()
+		program := p.ParseProgram()
 		checkParseErrors(t, p)
 		if program == nil {
 			t.Errorf("parseProgram returned nil")
@@ -233,7 +242,7 @@ func TestInfixExpression(t *testing.T) {
 	for _, tt := range tests {
 		l := lexer.New(tt.input)
 		p := New(l)
-		program := p.parseProgram()
+		program := p.ParseProgram()
 		checkParseErrors(t, p)
 		if program == nil {
 			t.Errorf("parseProgram returned nil")
@@ -260,3 +269,282 @@ func TestInfixExpression(t *testing.T) {
 		}
 	}
 }
+
+func TestWhileStatement(t *testing.T) {
+	input := `while (x < 10) { let y = x; break; continue; }`
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParseErrors(t, p)
+	if len(program.Statements) != 1 {
+		t.Fatalf("the len of Statements is not 1 instead %d", len(program.Statements))
+	}
+	stmt, ok := program.Statements[0].(*ast.WhileStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not WhileStatement instead we got %T", program.Statements[0])
+	}
+	if stmt.Condition.String() != "(x < 10)" {
+		t.Fatalf("stmt.Condition is not (x < 10) instead we got %q", stmt.Condition.String())
+	}
+	if len(stmt.Body.Statements) != 3 {
+		t.Fatalf("the len of Body.Statements is not 3 instead %d", len(stmt.Body.Statements))
+	}
+	if !testLetStatement(t, stmt.Body.Statements[0], "y") {
+		return
+	}
+	if _, ok := stmt.Body.Statements[1].(*ast.BreakStatement); !ok {
+		t.Fatalf("Body.Statements[1] is not BreakStatement instead we got %T", stmt.Body.Statements[1])
+	}
+	if _, ok := stmt.Body.Statements[2].(*ast.ContinueStatement); !ok {
+		t.Fatalf("Body.Statements[2] is not ContinueStatement instead we got %T", stmt.Body.Statements[2])
+	}
+}
+
+func TestForStatement(t *testing.T) {
+	input := `for (x in xs) { x; }`
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParseErrors(t, p)
+	if len(program.Statements) != 1 {
+		t.Fatalf("the len of Statements is not 1 instead %d", len(program.Statements))
+	}
+	stmt, ok := program.Statements[0].(*ast.ForStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ForStatement instead we got %T", program.Statements[0])
+	}
+	if stmt.Variable.Value != "x" {
+		t.Fatalf("stmt.Variable.Value is not x instead we got %q", stmt.Variable.Value)
+	}
+	if stmt.Iterable.String() != "xs" {
+		t.Fatalf("stmt.Iterable is not xs instead we got %q", stmt.Iterable.String())
+	}
+	if len(stmt.Body.Statements) != 1 {
+		t.Fatalf("the len of Body.Statements is not 1 instead %d", len(stmt.Body.Statements))
+	}
+}
+
+func TestBreakOutsideLoop(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"break;", "break outside of a loop"},
+		{"continue;", "continue outside of a loop"},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("expected error %q for %q instead we got %v", tt.expected, tt.input, errors)
+		}
+	}
+}
+
+func TestAssignExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		name     string
+		operator string
+		expected string
+	}{
+		{"x = 5;", "x", "=", "(x = 5);"},
+		{"x += 1;", "x", "+=", "(x += 1);"},
+		{"x -= 1;", "x", "-=", "(x -= 1);"},
+		{"x *= 2 + 3;", "x", "*=", "(x *= (2 + 3));"},
+		{"x /= 2;", "x", "/=", "(x /= 2);"},
+		{"x = y = 5;", "x", "=", "(x = (y = 5));"},
+	}
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParseErrors(t, p)
+		if len(program.Statements) != 1 {
+			t.Fatalf("the len of Statements is not 1 instead %d", len(program.Statements))
+		}
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ExpressionStatement instead we got %T", program.Statements[0])
+		}
+		exp, ok := stmt.Expression.(*ast.AssignExpression)
+		if !ok {
+			t.Fatalf("stmt.Expression is not AssignExpression instead we got %T", stmt.Expression)
+		}
+		if exp.Name.Value != tt.name {
+			t.Fatalf("exp.Name.Value is not %s instead we got %s", tt.name, exp.Name.Value)
+		}
+		if exp.Operator != tt.operator {
+			t.Fatalf("exp.Operator is not %s instead we got %s", tt.operator, exp.Operator)
+		}
+		if program.String() != tt.expected {
+			t.Fatalf("program.String() is not %q instead we got %q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestAssignToNonIdentifier(t *testing.T) {
+	p := New(lexer.New("1 + x = 5;"))
+	p.ParseProgram()
+	errors := p.Errors()
+	if len(errors) == 0 || errors[0] != "cannot assign to (1 + x)" {
+		t.Fatalf("expected cannot assign error instead we got %v", errors)
+	}
+}
+
+func TestConstStatement(t *testing.T) {
+	input := `const limit = 10;`
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParseErrors(t, p)
+	if len(program.Statements) != 1 {
+		t.Fatalf("the len of Statements is not 1 instead %d", len(program.Statements))
+	}
+	stmt, ok := program.Statements[0].(*ast.LetStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not LetStatement instead we got %T", program.Statements[0])
+	}
+	if !stmt.Constant {
+		t.Fatalf("stmt.Constant is not true")
+	}
+	if stmt.Name.Value != "limit" {
+		t.Fatalf("stmt.Name.Value is not limit instead we got %s", stmt.Name.Value)
+	}
+	if !testIntegerLiteral(t, stmt.Value, 10) {
+		return
+	}
+	if program.String() != "const limit = 10;" {
+		t.Fatalf("program.String() is not %q instead we got %q", "const limit = 10;", program.String())
+	}
+}
+
+func TestConstReassignment(t *testing.T) {
+	tests := []struct {
+		input  string
+		errors []string
+	}{
+		{"const x = 1; x = 2;", []string{"cannot reassign constant x"}},
+		{"const x = 1; x += 2;", []string{"cannot reassign constant x"}},
+		{"const x = 1; let x = 2;", []string{"cannot reassign constant x"}},
+		{"const x = 1; for (x in xs) { }", []string{"cannot reassign constant x"}},
+		{"let x = 1; x = 2; const y = x;", []string{}},
+		{"x = 2; const x = 1;", []string{}},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+		errors := p.Errors()
+		if len(errors) != len(tt.errors) {
+			t.Errorf("expected errors %v for %q instead we got %v", tt.errors, tt.input, errors)
+			continue
+		}
+		for i, msg := range tt.errors {
+			if errors[i] != msg {
+				t.Errorf("expected error %q for %q instead we got %q", msg, tt.input, errors[i])
+			}
+		}
+	}
+}
+
+func TestGroupedExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"(1 + 2) * 3;", "((1 + 2) * 3);"},
+		{"1 + (2 + 3) + 4;", "((1 + (2 + 3)) + 4);"},
+		{"-(5 + 5);", "(-(5 + 5));"},
+		{"((x));", "x;"},
+		{"x = (y = 1) + 2;", "(x = ((y = 1) + 2));"},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParseErrors(t, p)
+		if program.String() != tt.expected {
+			t.Errorf("program.String() is not %q instead we got %q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestUnterminatedLoopBody(t *testing.T) {
+	tests := []string{
+		"while (x) { let y = 1;",
+		"for (x in xs) { x;",
+		"while (x) { for (y in ys) { y; }",
+	}
+	for _, input := range tests {
+		p := New(lexer.New(input))
+		p.ParseProgram()
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != "expected next token to be } but got EOF instead" {
+			t.Errorf("expected a missing } error for %q instead we got %v", input, errors)
+		}
+	}
+}
+
+func TestFailedStatementsAreDropped(t *testing.T) {
+	tests := []string{
+		"while (x { y; }",
+		"for (1 in x) {}",
+		"let = 5;",
+		"while (x) { let = 1; }",
+	}
+	for _, input := range tests {
+		p := New(lexer.New(input))
+		program := p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parse errors for %q", input)
+		}
+		ast.Inspect(program, func(node ast.Node) bool {
+			if block, ok := node.(*ast.BlockStatement); ok {
+				checkNoNilStatements(t, input, block.Statements)
+			}
+			return true
+		})
+		checkNoNilStatements(t, input, program.Statements)
+	}
+}
+
+func checkNoNilStatements(t *testing.T, input string, stmts []ast.Statement) {
+	for i, stmt := range stmts {
+		if reflect.ValueOf(stmt).IsNil() {
+			t.Errorf("statement %d of %q is a nil %T", i, input, stmt)
+		}
+	}
+}
+
+func TestDeclareConstants(t *testing.T) {
+	p := New(lexer.New("x = 2; const y = 3;"))
+	p.DeclareConstants("x")
+	p.ParseProgram()
+	errors := p.Errors()
+	if len(errors) != 1 || errors[0] != "cannot reassign constant x" {
+		t.Fatalf("expected cannot reassign constant x instead we got %v", errors)
+	}
+	constants := p.Constants()
+	if len(constants) != 2 || constants[0] != "x" || constants[1] != "y" {
+		t.Fatalf("p.Constants() is not [x y] instead we got %v", constants)
+	}
+}
+
+func TestLoopString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"while (x) { a; } b;", "while (x) { a; }b;"},
+		{"for (x in xs) { a; b; }", "for (x in xs) { a; b; }"},
+		{"while (x) {}", "while (x) { }"},
+	}
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParseErrors(t, p)
+		if program.String() != tt.expected {
+			t.Errorf("program.String() is not %q instead we got %q", tt.expected, program.String())
+		}
+	}
+}
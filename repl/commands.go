@@ -0,0 +1,134 @@
+package repl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nishokbanand/interpreter/ast"
+	"github.com/nishokbanand/interpreter/lexer"
+	"github.com/nishokbanand/interpreter/token"
+)
+
+type command struct {
+	name string
+	args string
+	help string
+}
+
+// commands lists the meta-commands understood by runCommand.
+var commands = []command{
+	{":ast", "<input>", "show the parsed statements of input"},
+	{":env", "", "list the bindings made in this session"},
+	{":help", "", "show this help"},
+	{":load", "<file>", "parse file into the session"},
+	{":reset", "", "forget all inputs of this session"},
+	{":save", "<file>", "write the session's successful inputs to file"},
+	{":tokens", "<input>", "show the tokens of input"},
+}
+
+func (s *session) runCommand(out io.Writer, line string) {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case ":ast":
+		if program, _ := parse(out, arg, s.constants); program != nil {
+			for _, stmt := range program.Statements {
+				fmt.Fprintf(out, "\t%T %s\n", stmt, stmt.String())
+			}
+		}
+	case ":env":
+		s.printEnv(out)
+	case ":help":
+		for _, cmd := range commands {
+			fmt.Fprintf(out, "%-16s %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.help)
+		}
+	case ":load":
+		s.load(out, arg)
+	case ":reset":
+		s.inputs = nil
+		s.constants = nil
+	case ":save":
+		s.save(out, arg)
+	case ":tokens":
+		l := lexer.New(arg)
+		for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+			fmt.Fprintf(out, "%d:%d\t%s\t%q\n", tok.Line, tok.Column, tok.Type, tok.Literal)
+		}
+	default:
+		fmt.Fprintf(out, "unknown command %s, try :help\n", name)
+	}
+}
+
+func (s *session) load(out io.Writer, path string) {
+	if path == "" {
+		fmt.Fprintln(out, "usage: :load <file>")
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(out, "could not load %s: %v\n", path, err)
+		return
+	}
+	//the file is source only, so a leading ':' is not a meta-command
+	s.enter(out, string(data))
+}
+
+func (s *session) save(out io.Writer, path string) {
+	if path == "" {
+		fmt.Fprintln(out, "usage: :save <file>")
+		return
+	}
+	var b strings.Builder
+	for _, input := range s.inputs {
+		b.WriteString(input + "\n")
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		fmt.Fprintf(out, "could not save %s: %v\n", path, err)
+	}
+}
+
+// printEnv lists the latest top-level let and const binding of each name
+// entered in this session.
+func (s *session) printEnv(out io.Writer) {
+	bindings := s.bindings()
+	for _, name := range sortedNames(bindings) {
+		fmt.Fprintln(out, bindings[name].String())
+	}
+}
+
+// names returns the names bound in this session, sorted.
+func (s *session) names() []string {
+	return sortedNames(s.bindings())
+}
+
+// bindings returns the latest top-level let or const statement binding each
+// name entered in this session.
+func (s *session) bindings() map[string]*ast.LetStatement {
+	bindings := map[string]*ast.LetStatement{}
+	var constants []string
+	for _, input := range s.inputs {
+		var program *ast.Program
+		program, constants = parse(io.Discard, input, constants)
+		if program == nil {
+			continue
+		}
+		for _, stmt := range program.Statements {
+			if let, ok := stmt.(*ast.LetStatement); ok {
+				bindings[let.Name.Value] = let
+			}
+		}
+	}
+	return bindings
+}
+
+func sortedNames(bindings map[string]*ast.LetStatement) []string {
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,108 @@
+package repl
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSessionCommands(t *testing.T) {
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib.mk")
+	if err := os.WriteFile(lib, []byte("const limit = 10;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	saved := filepath.Join(dir, "saved.mk")
+
+	s := &session{}
+	steps := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1;\n", "let x = 1;\n"},
+		{"let = 2;\n", "parser errors:\n\texpected next token to be IDENT but got = instead\n\tno PrefixParseFunc found for =\n"},
+		{":load " + lib, "const limit = 10;\n"},
+		{"let x = 2 * 3;\n", "let x = (2 * 3);\n"},
+		{"\n", ""},
+		{":env", "const limit = 10;\nlet x = (2 * 3);\n"},
+		{":save " + saved, ""},
+		{":load", "usage: :load <file>\n"},
+		{":reset", ""},
+		{":env", ""},
+	}
+	for _, step := range steps {
+		var out bytes.Buffer
+		s.process(&out, step.input)
+		if out.String() != step.expected {
+			t.Errorf("process(%q) wrong. expected=%q got=%q", step.input, step.expected, out.String())
+		}
+	}
+
+	data, err := os.ReadFile(saved)
+	if err != nil {
+		t.Fatalf("session was not saved: %v", err)
+	}
+	expected := "let x = 1;\nconst limit = 10;\nlet x = 2 * 3;\n"
+	if string(data) != expected {
+		t.Fatalf("saved session wrong. expected=%q got=%q", expected, data)
+	}
+}
+
+func TestHelpListsCommands(t *testing.T) {
+	var out bytes.Buffer
+	(&session{}).process(&out, ":help")
+	for _, cmd := range commands {
+		if !strings.Contains(out.String(), cmd.name) {
+			t.Errorf(":help does not mention %s, got %q", cmd.name, out.String())
+		}
+	}
+}
+
+func TestSessionKeepsConstants(t *testing.T) {
+	lib := filepath.Join(t.TempDir(), "lib.mk")
+	if err := os.WriteFile(lib, []byte("const limit = 10;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	input := "const x = 1;\nx = 2;\nlet x = 3;\n:ast x = 4\n:load " + lib + "\nlimit += 1;\n:env\n:reset\nlet x = 4;\n"
+	transcript := ">>const x = 1;\n" +
+		">>parser errors:\n\tcannot reassign constant x\n" +
+		">>parser errors:\n\tcannot reassign constant x\n" +
+		">>parser errors:\n\tcannot reassign constant x\n" +
+		">>const limit = 10;\n" +
+		">>parser errors:\n\tcannot reassign constant limit\n" +
+		">>const limit = 10;\nconst x = 1;\n" +
+		">>>>let x = 4;\n>>"
+	var out bytes.Buffer
+	Start(strings.NewReader(input), &out)
+	if out.String() != transcript {
+		t.Fatalf("transcript wrong.\nexpected=%q\ngot=%q", transcript, out.String())
+	}
+}
+
+func TestLoadIsSourceOnly(t *testing.T) {
+	dir := t.TempDir()
+	reset := filepath.Join(dir, "reset.mk")
+	if err := os.WriteFile(reset, []byte(":reset\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	self := filepath.Join(dir, "self.mk")
+	if err := os.WriteFile(self, []byte(":load "+self+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &session{}
+	s.process(io.Discard, "let x = 1;")
+	for _, path := range []string{reset, self} {
+		var out bytes.Buffer
+		s.process(&out, ":load "+path)
+		if !strings.HasPrefix(out.String(), "parser errors:\n") {
+			t.Errorf(":load %s should report parser errors, got %q", path, out.String())
+		}
+	}
+	if len(s.inputs) != 1 || s.inputs[0] != "let x = 1;" {
+		t.Fatalf("session inputs changed by :load, got %q", s.inputs)
+	}
+}
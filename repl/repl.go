@@ -4,25 +4,160 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 
+	"github.com/nishokbanand/interpreter/ast"
 	"github.com/nishokbanand/interpreter/lexer"
+	"github.com/nishokbanand/interpreter/parser"
 	"github.com/nishokbanand/interpreter/token"
+	"golang.org/x/term"
 )
 
-const prompt = ">>"
+const (
+	prompt             = ">>"
+	continuationPrompt = ".."
+)
 
+// Start runs the REPL, with line editing, history and tab completion when in
+// is a terminal and reading plain lines otherwise.
 func Start(in io.Reader, out io.Writer) {
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		if err := startTerminal(f, out); err == nil {
+			return
+		}
+	}
 	scanner := bufio.NewScanner(in)
+	run(&session{}, out, func(prompt string) (string, bool) {
+		fmt.Fprint(out, prompt)
+		if !scanner.Scan() {
+			return "", false
+		}
+		return scanner.Text(), true
+	})
+}
+
+// run reads lines until readLine reports the end of input, buffering them
+// until they form a complete input and processing it in s.
+func run(s *session, out io.Writer, readLine func(prompt string) (string, bool)) {
+	text := ""
 	for {
-		fmt.Print(prompt)
-		scanned := scanner.Scan()
-		if !scanned {
+		p := prompt
+		if text != "" {
+			p = continuationPrompt
+		}
+		line, ok := readLine(p)
+		if !ok {
 			return
 		}
-		text := scanner.Text()
-		l := lexer.New(text)
-		for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
-			fmt.Printf("%v\n", tok)
+		//meta-commands are a single line, whatever their argument looks like
+		if text == "" && strings.HasPrefix(strings.TrimSpace(line), ":") {
+			s.process(out, line)
+			continue
 		}
+		text += line + "\n"
+		if !isComplete(text) {
+			continue
+		}
+		s.process(out, text)
+		text = ""
+	}
+}
+
+// session holds the state shared by the inputs of one REPL run.
+type session struct {
+	//inputs that parsed without errors, in the order they were entered
+	inputs []string
+	//names bound with const by those inputs
+	constants []string
+}
+
+// process handles one complete input, which is either a meta-command such as
+// ":tokens 5 + 5" or source to be parsed.
+func (s *session) process(out io.Writer, input string) {
+	trimmed := strings.TrimSpace(input)
+	if strings.HasPrefix(trimmed, ":") {
+		s.runCommand(out, trimmed)
+		return
+	}
+	s.enter(out, input)
+}
+
+// enter parses source input and records it in the session if it parses.
+func (s *session) enter(out io.Writer, input string) {
+	//blank lines are neither echoed nor kept for :save
+	if strings.TrimSpace(input) == "" {
+		return
+	}
+	program, constants := parse(out, input, s.constants)
+	if program == nil {
+		return
+	}
+	s.inputs = append(s.inputs, strings.TrimRight(input, "\n"))
+	s.constants = constants
+	fmt.Fprintln(out, program.String())
+}
+
+// parse parses input with constants already declared, printing any parser
+// errors to out and returning nil if there were some. Otherwise it also
+// returns the constants declared once input is added.
+func parse(out io.Writer, input string, constants []string) (*ast.Program, []string) {
+	p := parser.New(lexer.New(input))
+	p.DeclareConstants(constants...)
+	program := p.ParseProgram()
+	if errors := p.Errors(); len(errors) != 0 {
+		PrintParserErrors(out, errors)
+		return nil, constants
+	}
+	return program, p.Constants()
+}
+
+// PrintParserErrors writes errors to out, one per line under a heading.
+func PrintParserErrors(out io.Writer, errors []string) {
+	fmt.Fprintln(out, "parser errors:")
+	for _, msg := range errors {
+		fmt.Fprintf(out, "\t%s\n", msg)
+	}
+}
+
+// operators that need a right hand side, so input ending in one continues
+// on the next line
+var continuations = map[token.TokenType]bool{
+	token.ASSIGN:          true,
+	token.PLUS:            true,
+	token.MINUS:           true,
+	token.NOT:             true,
+	token.ASTERISK:        true,
+	token.FRWDSLASH:       true,
+	token.LESSTHAN:        true,
+	token.GREATERTHAN:     true,
+	token.EQUALS:          true,
+	token.NOTEQUALS:       true,
+	token.PLUSASSIGN:      true,
+	token.MINUSASSIGN:     true,
+	token.ASTERISKASSIGN:  true,
+	token.FRWDSLASHASSIGN: true,
+	token.COMMA:           true,
+}
+
+// isComplete reports whether input can be handed to the parser, i.e. all
+// braces and parens are closed and it does not end in a binary operator.
+func isComplete(input string) bool {
+	depth := 0
+	last := token.Token{Type: token.EOF}
+	l := lexer.New(input)
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		switch tok.Type {
+		case token.LPAREN, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACE:
+			depth--
+		}
+		last = tok
+	}
+	//extra closers can never be balanced, so leave them for the parser to report
+	if depth > 0 {
+		return false
 	}
+	return !continuations[last.Type]
 }
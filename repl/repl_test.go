@@ -0,0 +1,108 @@
+package repl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestIsComplete(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"", true},
+		{"5 + 5;", true},
+		{"let x = 5", true},
+		{"let x =", false},
+		{"5 +", false},
+		{"x +=", false},
+		{"while (x < 10) {", false},
+		{"while (x < 10) {\nx += 1;\n}", true},
+		{"while ((x < 10)", false},
+		{"x)", true},
+		{"add(1,", false},
+	}
+	for _, tt := range tests {
+		if got := isComplete(tt.input); got != tt.expected {
+			t.Errorf("isComplete(%q) wrong. expected=%t got=%t", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 5 * 2;", "let x = (5 * 2);\n"},
+		{"let = 5;", "parser errors:\n\texpected next token to be IDENT but got = instead\n\tno PrefixParseFunc found for =\n"},
+		{":tokens x += 1", "1:1\tIDENT\t\"x\"\n1:3\t+=\t\"+=\"\n1:6\tINT\t\"1\"\n"},
+		{":ast x = 1; 2", "\t*ast.ExpressionStatement (x = 1);\n\t*ast.ExpressionStatement 2;\n"},
+		{":nope", "unknown command :nope, try :help\n"},
+		{" \t\n", ""},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		(&session{}).process(&out, tt.input)
+		if out.String() != tt.expected {
+			t.Errorf("process(%q) wrong. expected=%q got=%q", tt.input, tt.expected, out.String())
+		}
+	}
+}
+
+func TestStart(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		transcript string
+	}{
+		{
+			"empty input",
+			"",
+			">>",
+		},
+		{
+			"single line",
+			"let x = 1 + 2;\n",
+			">>let x = (1 + 2);\n>>",
+		},
+		{
+			"multi line",
+			"while (x < 10) {\nx += 1;\n}\n",
+			">>....while ((x < 10)) { (x += 1); }\n>>",
+		},
+		{
+			"parser errors",
+			"let 5;\nx;\n",
+			">>parser errors:\n\texpected next token to be IDENT but got INT instead\n>>x;\n>>",
+		},
+		{
+			"blank lines",
+			"\n  \nx;\n",
+			">>>>>>x;\n>>",
+		},
+		{
+			"meta-command",
+			":tokens -5\n",
+			">>1:1\t-\t\"-\"\n1:2\tINT\t\"5\"\n>>",
+		},
+		{
+			"meta-command with unfinished argument",
+			":tokens 5 +\n:ast (x\nx;\n",
+			">>1:1\tINT\t\"5\"\n1:3\t+\t\"+\"\n>>parser errors:\n\texpected next token to be ) but got EOF instead\n>>x;\n>>",
+		},
+		{
+			"unfinished input at end",
+			"let x =\n",
+			">>..",
+		},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		Start(strings.NewReader(tt.input), &out)
+		if out.String() != tt.transcript {
+			t.Errorf("%s: transcript wrong. expected=%q got=%q", tt.name, tt.transcript, out.String())
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package repl
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nishokbanand/interpreter/token"
+	"golang.org/x/term"
+)
+
+const (
+	historyFile = ".monkey_history"
+	historyMax  = 1000
+)
+
+// keys not handled by term.Terminal itself that are passed to the completer
+const (
+	keyTab     = '\t'
+	keyReverse = 'r' & 0x1f //ctrl-r
+)
+
+func startTerminal(f *os.File, out io.Writer) error {
+	fd := int(f.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{f, out}, prompt)
+	history := loadHistory(historyPath())
+	t.History = history
+	s := &session{}
+	c := &completer{history: history, names: s.names}
+	t.AutoCompleteCallback = c.complete
+	run(s, t, func(p string) (string, bool) {
+		t.SetPrompt(p)
+		line, err := t.ReadLine()
+		return line, err == nil
+	})
+	return nil
+}
+
+func historyPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, historyFile)
+}
+
+// fileHistory is a term.History that appends every entry to a file so it
+// survives between sessions. An empty path keeps the history in memory only.
+// The file is cut back to the last historyMax entries whenever it is loaded,
+// so it grows by at most one session's worth of entries past that.
+type fileHistory struct {
+	path    string
+	entries []string
+}
+
+func loadHistory(path string) *fileHistory {
+	h := &fileHistory{path: path}
+	if path == "" {
+		return h
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return h
+	}
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		h.push(scanner.Text())
+		lines++
+	}
+	f.Close()
+	if lines > len(h.entries) {
+		h.save()
+	}
+	return h
+}
+
+// save replaces the file with the entries kept in memory.
+func (h *fileHistory) save() {
+	var b strings.Builder
+	for _, entry := range h.entries {
+		b.WriteString(entry + "\n")
+	}
+	os.WriteFile(h.path, []byte(b.String()), 0600)
+}
+
+func (h *fileHistory) push(entry string) {
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > historyMax {
+		h.entries = h.entries[len(h.entries)-historyMax:]
+	}
+}
+
+func (h *fileHistory) Add(entry string) {
+	if strings.TrimSpace(entry) == "" {
+		return
+	}
+	h.push(entry)
+	if h.path == "" {
+		return
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.WriteString(entry + "\n")
+}
+
+func (h *fileHistory) Len() int {
+	return len(h.entries)
+}
+
+// At returns the entry idx places back from the most recent one.
+func (h *fileHistory) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+// completer implements tab completion and ctrl-r reverse history search.
+type completer struct {
+	history *fileHistory
+	//names bound in the session, offered alongside the keywords
+	names func() []string
+	//state of the last reverse search so repeated ctrl-r finds older matches
+	query string
+	match string
+	index int
+}
+
+func (c *completer) complete(line string, pos int, key rune) (string, int, bool) {
+	switch key {
+	case keyTab:
+		return c.completeWord(line, pos)
+	case keyReverse:
+		return c.reverseSearch(line)
+	}
+	return "", 0, false
+}
+
+func (c *completer) completeWord(line string, pos int) (string, int, bool) {
+	start := pos
+	for start > 0 && isWordChar(line[start-1]) {
+		start--
+	}
+	word := line[start:pos]
+	if word == "" {
+		return "", 0, false
+	}
+	candidates := token.Keywords()
+	if c.names != nil {
+		candidates = append(candidates, c.names()...)
+	}
+	if start == 0 {
+		for _, cmd := range commands {
+			candidates = append(candidates, cmd.name)
+		}
+	}
+	completion := ""
+	found := false
+	for _, candidate := range candidates {
+		if !strings.HasPrefix(candidate, word) {
+			continue
+		}
+		if !found {
+			completion = candidate
+			found = true
+			continue
+		}
+		completion = commonPrefix(completion, candidate)
+	}
+	if !found || completion == word {
+		return "", 0, false
+	}
+	return line[:start] + completion + line[pos:], start + len(completion), true
+}
+
+func (c *completer) reverseSearch(line string) (string, int, bool) {
+	from := 0
+	if line == c.match && c.match != "" {
+		from = c.index + 1
+	} else {
+		c.query = line
+	}
+	for i := from; i < c.history.Len(); i++ {
+		entry := c.history.At(i)
+		if strings.Contains(entry, c.query) {
+			c.match = entry
+			c.index = i
+			return entry, len(entry), true
+		}
+	}
+	return "", 0, false
+}
+
+func isWordChar(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || ch == ':'
+}
+
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return a[:i]
+}
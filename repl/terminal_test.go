@@ -0,0 +1,135 @@
+package repl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompleteWord(t *testing.T) {
+	tests := []struct {
+		line        string
+		pos         int
+		expected    string
+		expectedPos int
+		ok          bool
+	}{
+		{"wh", 2, "while", 5, true},
+		{"let x = tr", 10, "let x = true", 12, true},
+		{"co", 2, "con", 3, true},
+		{"cont", 4, "continue", 8, true},
+		{"f(x)", 1, "f(x)", 1, false},
+		{":to", 3, ":tokens", 7, true},
+		{"x :to", 5, "", 0, false},
+		{"while", 5, "", 0, false},
+		{"", 0, "", 0, false},
+	}
+	c := &completer{history: &fileHistory{}}
+	for _, tt := range tests {
+		line, pos, ok := c.complete(tt.line, tt.pos, keyTab)
+		if ok != tt.ok {
+			t.Errorf("complete(%q) ok wrong. expected=%t got=%t", tt.line, tt.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if line != tt.expected || pos != tt.expectedPos {
+			t.Errorf("complete(%q) wrong. expected=%q,%d got=%q,%d", tt.line, tt.expected, tt.expectedPos, line, pos)
+		}
+	}
+}
+
+func TestCompleteSessionNames(t *testing.T) {
+	s := &session{}
+	s.process(io.Discard, "let counter = 1;")
+	s.process(io.Discard, "const limit_max = 10;")
+	c := &completer{history: &fileHistory{}, names: s.names}
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"cou", "counter"},
+		{"x += lim", "x += limit_max"},
+		{"co", ""},
+	}
+	for _, tt := range tests {
+		line, pos, ok := c.complete(tt.line, len(tt.line), keyTab)
+		if ok != (tt.expected != "") {
+			t.Errorf("complete(%q) ok wrong, got %t", tt.line, ok)
+			continue
+		}
+		if ok && (line != tt.expected || pos != len(tt.expected)) {
+			t.Errorf("complete(%q) wrong. expected=%q got=%q,%d", tt.line, tt.expected, line, pos)
+		}
+	}
+}
+
+func TestReverseSearch(t *testing.T) {
+	history := &fileHistory{}
+	history.Add("let x = 1;")
+	history.Add("x + 2;")
+	history.Add("let y = x;")
+	c := &completer{history: history}
+
+	expected := []string{"let y = x;", "let x = 1;"}
+	line := "let"
+	for _, want := range expected {
+		got, pos, ok := c.complete(line, len(line), keyReverse)
+		if !ok || got != want || pos != len(want) {
+			t.Fatalf("reverse search for %q wrong. expected=%q got=%q,%d,%t", line, want, got, pos, ok)
+		}
+		line = got
+	}
+	if _, _, ok := c.complete(line, len(line), keyReverse); ok {
+		t.Fatalf("reverse search past the oldest match should not change the line")
+	}
+}
+
+func TestFileHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), historyFile)
+	h := loadHistory(path)
+	h.Add("let x = 1;")
+	h.Add("  ")
+	h.Add("x;")
+	if h.Len() != 2 || h.At(0) != "x;" || h.At(1) != "let x = 1;" {
+		t.Fatalf("history entries wrong, got %q", h.entries)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("history file not written: %v", err)
+	}
+	if string(data) != "let x = 1;\nx;\n" {
+		t.Fatalf("history file wrong, got %q", data)
+	}
+	reloaded := loadHistory(path)
+	if reloaded.Len() != 2 || reloaded.At(0) != "x;" {
+		t.Fatalf("reloaded history wrong, got %q", reloaded.entries)
+	}
+}
+
+func TestFileHistoryTrimsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), historyFile)
+	var b strings.Builder
+	for i := 0; i < historyMax+10; i++ {
+		fmt.Fprintf(&b, "%d;\n", i)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		t.Fatal(err)
+	}
+	h := loadHistory(path)
+	if h.Len() != historyMax || h.At(historyMax-1) != "10;" {
+		t.Fatalf("history not trimmed, got %d entries", h.Len())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != historyMax || lines[0] != "10;" || lines[len(lines)-1] != fmt.Sprintf("%d;", historyMax+9) {
+		t.Fatalf("history file not trimmed, got %d lines starting with %q", len(lines), lines[0])
+	}
+}
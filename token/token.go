@@ -1,10 +1,16 @@
 package token
 
+import "sort"
+
 type TokenType string
 
 type Token struct {
-	Type    TokenType
-	Literal string
+	Type    TokenType `json:"type"`
+	Literal string    `json:"literal"`
+	// Line and Column locate the first character of the token, both
+	// starting at 1.
+	Line   int `json:"line"`
+	Column int `json:"column"`
 }
 
 const (
@@ -13,12 +19,18 @@ const (
 	EOF     = "EOF"
 	//keywords
 	LET      = "LET"
+	CONST    = "CONST"
 	FUNCTION = "FUNCTION"
 	TRUE     = "TRUE"
 	FALSE    = "FALSE"
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
+	WHILE    = "WHILE"
+	FOR      = "FOR"
+	IN       = "IN"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
 	//identifier
 	IDENT = "IDENT"
 	//literals
@@ -34,6 +46,11 @@ const (
 	GREATERTHAN = ">"
 	EQUALS      = "=="
 	NOTEQUALS   = "!="
+	//assignment operators
+	PLUSASSIGN      = "+="
+	MINUSASSIGN     = "-="
+	ASTERISKASSIGN  = "*="
+	FRWDSLASHASSIGN = "/="
 	//delimiters
 	COMMA     = ","
 	SEMICOLON = ";"
@@ -44,13 +61,19 @@ const (
 )
 
 var keywords = map[string]TokenType{
-	"fn":     FUNCTION,
-	"let":    LET,
-	"true":   TRUE,
-	"false":  FALSE,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
+	"fn":       FUNCTION,
+	"let":      LET,
+	"const":    CONST,
+	"true":     TRUE,
+	"false":    FALSE,
+	"if":       IF,
+	"else":     ELSE,
+	"return":   RETURN,
+	"while":    WHILE,
+	"for":      FOR,
+	"in":       IN,
+	"break":    BREAK,
+	"continue": CONTINUE,
 }
 
 func LookUpIdentifier(ident string) TokenType {
@@ -59,3 +82,13 @@ func LookUpIdentifier(ident string) TokenType {
 	}
 	return IDENT
 }
+
+// Keywords returns the reserved words of the language in sorted order.
+func Keywords() []string {
+	words := make([]string, 0, len(keywords))
+	for word := range keywords {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}